@@ -0,0 +1,5 @@
+#!/bin/sh
+set -eu
+
+terraform init -input=false
+terraform apply -input=false -auto-approve
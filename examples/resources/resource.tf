@@ -17,5 +17,116 @@ data "yoloexp_notion_page" "example" {
 }
 
 resource "yoloexp_notion_database" "example" {
+  parent_id   = data.yoloexp_notion_page.example.id
+  title       = "Tasks"
+  description = "Managed by Terraform."
+
+  properties = {
+    Name     = { type = "title" }
+    Estimate = { type = "number", number = { format = "number_with_commas" } }
+    Done     = { type = "checkbox" }
+  }
+}
+
+resource "yoloexp_notion_page" "example" {
+  parent_id = data.yoloexp_notion_page.example.id
+  title     = "Runbook"
+  icon      = { emoji = "📘" }
+
+  content_markdown = file("${path.module}/runbook.md")
+}
+
+resource "yoloexp_notion_database_rows" "example" {
+  database_id  = yoloexp_notion_database.example.id
+  key_property = "Name"
+
+  rows = {
+    "Write runbook" = { properties = { Estimate = { number = 3 } } }
+    "Review runbook" = {
+      properties = {
+        Estimate = { number = 1 }
+        Done     = { checkbox = true }
+      }
+    }
+  }
+}
+
+resource "yoloexp_notion_block" "example" {
+  parent_id = data.yoloexp_notion_page.example.id
+
+  callout = {
+    text  = "Deploys are **frozen** until Monday."
+    color = "yellow_background"
+    icon  = { emoji = "🚧" }
+  }
+}
+
+resource "yoloexp_notion_block" "deploy_script" {
   parent_id = data.yoloexp_notion_page.example.id
+  after     = yoloexp_notion_block.example.id
+
+  code = {
+    source_file = "${path.module}/deploy.sh"
+    language    = "shell"
+    caption     = "Published from `deploy.sh`."
+  }
+}
+
+resource "yoloexp_notion_block" "oncall" {
+  parent_id = data.yoloexp_notion_page.example.id
+  after     = yoloexp_notion_block.deploy_script.id
+
+  table = {
+    columns = ["Service", "Primary", "Secondary"]
+    rows = [
+      { Service = "api", Primary = "alice", Secondary = "bob" },
+      { Service = "web", Primary = "carol", Secondary = "dave" },
+    ]
+  }
+}
+
+resource "yoloexp_notion_block" "inventory" {
+  parent_id = data.yoloexp_notion_page.example.id
+  after     = yoloexp_notion_block.oncall.id
+
+  table = {
+    source_file    = "${path.module}/inventory.csv"
+    has_row_header = true
+  }
+}
+
+resource "yoloexp_notion_block" "escalation" {
+  parent_id = data.yoloexp_notion_page.example.id
+
+  synced_block = {
+    content_markdown = <<-EOT
+      ## Escalation contacts
+
+      - Primary: @oncall-primary
+      - Secondary: @oncall-secondary
+    EOT
+
+    # Adds copies at the end of these pages. Their ids are in copies and
+    # copy_page_ids.
+    copy_parent_ids = [yoloexp_notion_page.runbook.id]
+  }
+}
+
+resource "yoloexp_notion_page" "runbook" {
+  parent_id = data.yoloexp_notion_page.example.id
+  title     = "Runbook"
+}
+
+resource "yoloexp_notion_page" "oncall" {
+  parent_id = data.yoloexp_notion_page.example.id
+  title     = "On-call"
+}
+
+# Shows the escalation contacts on the on-call page as well.
+resource "yoloexp_notion_block" "oncall_escalation" {
+  parent_id = yoloexp_notion_page.oncall.id
+
+  synced_block = {
+    synced_from = yoloexp_notion_block.escalation.id
+  }
 }
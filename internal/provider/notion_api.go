@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+const (
+	notionAPIURL     = "https://api.notion.com/v1/"
+	notionAPIVersion = "2022-06-28"
+	notionMaxRetries = 3
+)
+
+// notionClient is the Notion client the provider passes to resources and
+// data sources. Requests that notionapi doesn't support yet, see
+// notionRequest, are sent with the same HTTP client, API version and retry
+// limit.
+type notionClient struct {
+	*notionapi.Client
+
+	httpClient *http.Client
+	baseURL    string
+}
+
+// newNotionClient returns a client authenticating with token that sends its
+// requests with httpClient.
+func newNotionClient(token notionapi.Token, httpClient *http.Client) *notionClient {
+	return &notionClient{
+		Client: notionapi.NewClient(token,
+			notionapi.WithHTTPClient(httpClient),
+			notionapi.WithVersion(notionAPIVersion),
+			notionapi.WithRetry(notionMaxRetries),
+		),
+		httpClient: httpClient,
+		baseURL:    notionAPIURL,
+	}
+}
+
+// notionDatabaseRequest is the request body to create or update a database.
+// It covers fields notionapi.DatabaseCreateRequest and
+// notionapi.DatabaseUpdateRequest don't support yet.
+type notionDatabaseRequest struct {
+	Parent      *notionapi.Parent         `json:"parent,omitempty"`
+	Title       []notionapi.RichText      `json:"title,omitempty"`
+	Description *[]notionapi.RichText     `json:"description,omitempty"`
+	Properties  notionapi.PropertyConfigs `json:"properties,omitempty"`
+	IsInline    *bool                     `json:"is_inline,omitempty"`
+	// Icon and Cover hold the new value or notionNull to remove it.
+	Icon  any `json:"icon,omitempty"`
+	Cover any `json:"cover,omitempty"`
+}
+
+// notionDatabase is a database returned by the Notion API. Relation
+// properties are decoded as *notionRelationPropertyConfig, which unlike
+// notionapi.RelationPropertyConfig keeps the synced property of dual
+// relations, and formula expressions reference properties by name.
+type notionDatabase struct {
+	notionapi.Database
+
+	// PropertyIDs maps property names to property ids.
+	PropertyIDs map[string]string `json:"-"`
+}
+
+func (db *notionDatabase) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &db.Database); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	db.PropertyIDs = make(map[string]string, len(raw.Properties))
+	names := make(map[string]string, len(raw.Properties))
+	for name, c := range raw.Properties {
+		var p struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(c, &p); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+		db.PropertyIDs[name] = p.ID
+		names[unescapePropertyID(p.ID)] = name
+	}
+
+	for name, c := range db.Properties {
+		switch v := c.(type) {
+		case *notionapi.RelationPropertyConfig:
+			rc := &notionRelationPropertyConfig{}
+			if err := json.Unmarshal(raw.Properties[name], rc); err != nil {
+				return fmt.Errorf("property %q: %w", name, err)
+			}
+			db.Properties[name] = rc
+		case *notionapi.FormulaPropertyConfig:
+			v.Formula.Expression = normalizeFormulaExpression(v.Formula.Expression, names)
+		}
+	}
+	return nil
+}
+
+// getDatabase gets the database with the given id.
+func getDatabase(ctx context.Context, client *notionClient, id notionapi.DatabaseID) (*notionDatabase, error) {
+	if id == "" {
+		return nil, errors.New("empty database id")
+	}
+	var db notionDatabase
+	if err := notionRequest(ctx, client, http.MethodGet, "databases/"+id.String(), nil, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// createDatabase creates a database.
+func createDatabase(ctx context.Context, client *notionClient, body *notionDatabaseRequest) (*notionDatabase, error) {
+	var db notionDatabase
+	if err := notionRequest(ctx, client, http.MethodPost, "databases", body, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// updateDatabase updates the database with the given id.
+func updateDatabase(ctx context.Context, client *notionClient, id notionapi.DatabaseID, body *notionDatabaseRequest) (*notionDatabase, error) {
+	var db notionDatabase
+	if err := notionRequest(ctx, client, http.MethodPatch, "databases/"+id.String(), body, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// queryDatabasePages returns all the pages of a database, following
+// pagination.
+func queryDatabasePages(ctx context.Context, client *notionClient, id notionapi.DatabaseID) ([]notionapi.Page, error) {
+	var pages []notionapi.Page
+	req := &notionapi.DatabaseQueryRequest{PageSize: 100}
+	for {
+		resp, err := client.Database.Query(ctx, id, req)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, resp.Results...)
+		if !resp.HasMore {
+			return pages, nil
+		}
+		req.StartCursor = resp.NextCursor
+	}
+}
+
+// archivePage moves a page to the trash. Pages that no longer exist are
+// ignored.
+func archivePage(ctx context.Context, client *notionClient, id string) error {
+	_, err := client.Page.Update(ctx, notionapi.PageID(id), &notionapi.PageUpdateRequest{
+		Properties: notionapi.Properties{},
+		Archived:   true,
+	})
+	if err != nil && !isNotionNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// clearPageFields removes the given fields, such as the icon, of a page.
+func clearPageFields(ctx context.Context, client *notionClient, id notionapi.PageID, fields ...string) (*notionapi.Page, error) {
+	body := make(map[string]any, len(fields))
+	for _, f := range fields {
+		body[f] = notionNull
+	}
+	var page notionapi.Page
+	if err := notionRequest(ctx, client, http.MethodPatch, "pages/"+id.String(), body, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// clearBlockCaption removes the caption of a block of type t, such as a code
+// block or a bookmark.
+func clearBlockCaption(ctx context.Context, client *notionClient, id notionapi.BlockID, t notionapi.BlockType) error {
+	body := map[string]any{
+		string(t): map[string]any{"caption": []notionapi.RichText{}},
+	}
+	return notionRequest(ctx, client, http.MethodPatch, "blocks/"+id.String(), body, nil)
+}
+
+// updateTableHeaders sets whether the first row and the first column of a
+// table are headers.
+func updateTableHeaders(ctx context.Context, client *notionClient, id notionapi.BlockID, columnHeader, rowHeader bool) error {
+	body := map[string]any{
+		string(notionapi.BlockTypeTableBlock): map[string]any{
+			"has_column_header": columnHeader,
+			"has_row_header":    rowHeader,
+		},
+	}
+	return notionRequest(ctx, client, http.MethodPatch, "blocks/"+id.String(), body, nil)
+}
+
+// notionRequest sends a request to the Notion API through client and decodes
+// the response into out. Like the notionapi client, it retries rate limited
+// requests and returns API errors as *notionapi.Error.
+func notionRequest(ctx context.Context, client *notionClient, method, urlPath string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = b
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, client.baseURL+urlPath, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+client.Token.String())
+		req.Header.Set("Notion-Version", notionAPIVersion)
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := client.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case res.StatusCode == http.StatusOK:
+			if out == nil {
+				return nil
+			}
+			return json.Unmarshal(data, out)
+		case res.StatusCode == http.StatusTooManyRequests && attempt < notionMaxRetries:
+			wait, err := strconv.Atoi(res.Header.Get("Retry-After"))
+			if err != nil {
+				wait = 1
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(wait) * time.Second):
+			}
+		default:
+			apiErr := &notionapi.Error{}
+			if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Message == "" {
+				return fmt.Errorf("notion API returned status %d: %s", res.StatusCode, data)
+			}
+			return apiErr
+		}
+	}
+}
+
+// isNotionNotFound reports whether err is a Notion API error saying the
+// requested object doesn't exist or isn't shared with the integration.
+func isNotionNotFound(err error) bool {
+	var apiErr *notionapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == "object_not_found"
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotionRequest(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.Header.Get("Notion-Version"); got != notionAPIVersion {
+			t.Errorf("Notion-Version = %q, want %q", got, notionAPIVersion)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/blocks/limited":
+			if calls == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": "limited"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newNotionClient("secret", server.Client())
+	client.baseURL = server.URL + "/"
+
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := notionRequest(context.Background(), client, http.MethodGet, "blocks/limited", nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != "limited" || calls != 2 {
+		t.Errorf("notionRequest() = %q after %d calls, want the retried response", out.ID, calls)
+	}
+
+	err := notionRequest(context.Background(), client, http.MethodGet, "blocks/missing", nil, nil)
+	if !isNotionNotFound(err) {
+		t.Errorf("notionRequest() error = %v, want a not found API error", err)
+	}
+}
@@ -0,0 +1,523 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionBlockColors lists the text and background colors of blocks.
+var notionBlockColors = []string{
+	"default", "gray", "brown", "orange", "yellow", "green", "blue", "purple", "pink", "red",
+	"gray_background", "brown_background", "orange_background", "yellow_background", "green_background",
+	"blue_background", "purple_background", "pink_background", "red_background",
+}
+
+// notionTextBlockModel is a paragraph, list item, quote or toggle.
+type notionTextBlockModel struct {
+	Text  types.String `tfsdk:"text"`
+	Color types.String `tfsdk:"color"`
+}
+
+type notionHeadingBlockModel struct {
+	Text         types.String `tfsdk:"text"`
+	Color        types.String `tfsdk:"color"`
+	IsToggleable types.Bool   `tfsdk:"is_toggleable"`
+}
+
+type notionToDoBlockModel struct {
+	Text    types.String `tfsdk:"text"`
+	Color   types.String `tfsdk:"color"`
+	Checked types.Bool   `tfsdk:"checked"`
+}
+
+type notionCalloutBlockModel struct {
+	Text  types.String     `tfsdk:"text"`
+	Color types.String     `tfsdk:"color"`
+	Icon  *notionIconModel `tfsdk:"icon"`
+}
+
+type notionCodeBlockModel struct {
+	Code         types.String `tfsdk:"code"`
+	SourceFile   types.String `tfsdk:"source_file"`
+	SourceSHA256 types.String `tfsdk:"source_sha256"`
+	Language     types.String `tfsdk:"language"`
+	Caption      types.String `tfsdk:"caption"`
+
+	// Source is the content of SourceFile, set by loadCodeSource.
+	Source string `tfsdk:"-"`
+}
+
+// notionLinkBlockModel is a bookmark or an embed.
+type notionLinkBlockModel struct {
+	URL     types.String `tfsdk:"url"`
+	Caption types.String `tfsdk:"caption"`
+}
+
+type notionEquationBlockModel struct {
+	Expression types.String `tfsdk:"expression"`
+}
+
+type notionDividerBlockModel struct{}
+
+// blockTextAttributes returns the attributes of a block of text, with the
+// given attributes added.
+func blockTextAttributes(extra map[string]schema.Attribute) map[string]schema.Attribute {
+	attrs := map[string]schema.Attribute{
+		"text": schema.StringAttribute{
+			MarkdownDescription: "The text of the block as inline Markdown: bold, italic, strikethrough, inline code, links and `<u>underline</u>`.",
+			Required:            true,
+		},
+		"color": schema.StringAttribute{
+			MarkdownDescription: "The color of the block, `default` or a text or `_background` color.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(string(notionapi.ColorDefault)),
+			Validators: []validator.String{
+				stringvalidator.OneOf(notionBlockColors...),
+			},
+		},
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// blockCaptionAttribute returns the schema of the caption of a block.
+func blockCaptionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The caption of the block as inline Markdown.",
+		Optional:            true,
+	}
+}
+
+// blockKindAttributes returns the attributes of the kinds of blocks the block
+// resource manages, keyed by block type.
+func blockKindAttributes() map[string]schema.Attribute {
+	text := func(description string, extra map[string]schema.Attribute) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+			Attributes:          blockTextAttributes(extra),
+		}
+	}
+	heading := func(level int) schema.SingleNestedAttribute {
+		return text(fmt.Sprintf("A level %d heading.", level), map[string]schema.Attribute{
+			"is_toggleable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the heading is a toggle that can hide the blocks under it. Changing it re-creates the block.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		})
+	}
+	link := func(description string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			MarkdownDescription: description,
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"url": schema.StringAttribute{
+					MarkdownDescription: "The URL of the linked content.",
+					Required:            true,
+				},
+				"caption": blockCaptionAttribute(),
+			},
+		}
+	}
+
+	return map[string]schema.Attribute{
+		string(notionapi.BlockTypeParagraph):        text("A paragraph.", nil),
+		string(notionapi.BlockTypeHeading1):         heading(1),
+		string(notionapi.BlockTypeHeading2):         heading(2),
+		string(notionapi.BlockTypeHeading3):         heading(3),
+		string(notionapi.BlockTypeBulletedListItem): text("A bulleted list item.", nil),
+		string(notionapi.BlockTypeNumberedListItem): text("A numbered list item.", nil),
+		string(notionapi.BlockTypeToDo): text("A to-do list item.", map[string]schema.Attribute{
+			"checked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the to-do is checked.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		}),
+		string(notionapi.BlockTypeToggle): text("A toggle.", nil),
+		string(notionapi.BlockQuote):      text("A quote.", nil),
+		string(notionapi.BlockCallout): text("A callout.", map[string]schema.Attribute{
+			"icon": iconResourceAttribute("The callout's icon. If unset, Notion picks one and it is not managed."),
+		}),
+		string(notionapi.BlockTypeCode): schema.SingleNestedAttribute{
+			MarkdownDescription: "A code block.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"code": schema.StringAttribute{
+					MarkdownDescription: "The code, as plain text. Exactly one of `code` and `source_file` must be set.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_file")),
+					},
+				},
+				"source_file": schema.StringAttribute{
+					MarkdownDescription: "The path of a file to publish verbatim as the code, such as a script or a config snippet of the module.",
+					Optional:            true,
+				},
+				"source_sha256": schema.StringAttribute{
+					MarkdownDescription: "The SHA-256 checksum of the content of `source_file`. It changes when the file changes, or when the code is edited in Notion.",
+					Computed:            true,
+				},
+				"language": schema.StringAttribute{
+					MarkdownDescription: "The language of the code, one of the languages Notion highlights. Defaults to `plain text`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("plain text"),
+					Validators: []validator.String{
+						stringvalidator.OneOf(notionSupportedCodeLanguages...),
+					},
+				},
+				"caption": blockCaptionAttribute(),
+			},
+		},
+		string(notionapi.BlockTypeBookmark): link("A bookmark of a web page."),
+		string(notionapi.BlockTypeEmbed):    link("Embedded content, such as a video or a map."),
+		string(notionapi.BlockTypeEquation): schema.SingleNestedAttribute{
+			MarkdownDescription: "A block equation.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "The KaTeX expression of the equation.",
+					Required:            true,
+				},
+			},
+		},
+		string(notionapi.BlockTypeTableBlock):  tableBlockAttribute(),
+		string(notionapi.BlockTypeSyncedBlock): syncedBlockAttribute(),
+		string(notionapi.BlockTypeDivider): schema.SingleNestedAttribute{
+			MarkdownDescription: "A divider, set to `{}`.",
+			Optional:            true,
+			Attributes:          map[string]schema.Attribute{},
+		},
+	}
+}
+
+// blockTypes returns the types of the blocks configured by a block model.
+// A valid model configures exactly one.
+func blockTypes(m *notionBlockResourceModel) []notionapi.BlockType {
+	var ts []notionapi.BlockType
+	for _, k := range []struct {
+		t   notionapi.BlockType
+		set bool
+	}{
+		{notionapi.BlockTypeParagraph, m.Paragraph != nil},
+		{notionapi.BlockTypeHeading1, m.Heading1 != nil},
+		{notionapi.BlockTypeHeading2, m.Heading2 != nil},
+		{notionapi.BlockTypeHeading3, m.Heading3 != nil},
+		{notionapi.BlockTypeBulletedListItem, m.BulletedListItem != nil},
+		{notionapi.BlockTypeNumberedListItem, m.NumberedListItem != nil},
+		{notionapi.BlockTypeToDo, m.ToDo != nil},
+		{notionapi.BlockTypeToggle, m.Toggle != nil},
+		{notionapi.BlockQuote, m.Quote != nil},
+		{notionapi.BlockCallout, m.Callout != nil},
+		{notionapi.BlockTypeCode, m.Code != nil},
+		{notionapi.BlockTypeBookmark, m.Bookmark != nil},
+		{notionapi.BlockTypeEmbed, m.Embed != nil},
+		{notionapi.BlockTypeEquation, m.Equation != nil},
+		{notionapi.BlockTypeTableBlock, m.Table != nil},
+		{notionapi.BlockTypeSyncedBlock, m.SyncedBlock != nil},
+		{notionapi.BlockTypeDivider, m.Divider != nil},
+	} {
+		if k.set {
+			ts = append(ts, k.t)
+		}
+	}
+	return ts
+}
+
+// expandBlock converts the block configured by a block model into a Notion
+// block.
+func expandBlock(m *notionBlockResourceModel) notionapi.Block {
+	switch {
+	case m.Paragraph != nil:
+		return &notionapi.ParagraphBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeParagraph),
+			Paragraph:  notionapi.Paragraph{RichText: markdownRichText(m.Paragraph.Text.ValueString()), Color: m.Paragraph.Color.ValueString()},
+		}
+	case m.Heading1 != nil:
+		return &notionapi.Heading1Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading1), Heading1: expandHeading(m.Heading1)}
+	case m.Heading2 != nil:
+		return &notionapi.Heading2Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading2), Heading2: expandHeading(m.Heading2)}
+	case m.Heading3 != nil:
+		return &notionapi.Heading3Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading3), Heading3: expandHeading(m.Heading3)}
+	case m.BulletedListItem != nil:
+		return &notionapi.BulletedListItemBlock{
+			BasicBlock:       newBasicBlock(notionapi.BlockTypeBulletedListItem),
+			BulletedListItem: notionapi.ListItem{RichText: markdownRichText(m.BulletedListItem.Text.ValueString()), Color: m.BulletedListItem.Color.ValueString()},
+		}
+	case m.NumberedListItem != nil:
+		return &notionapi.NumberedListItemBlock{
+			BasicBlock:       newBasicBlock(notionapi.BlockTypeNumberedListItem),
+			NumberedListItem: notionapi.ListItem{RichText: markdownRichText(m.NumberedListItem.Text.ValueString()), Color: m.NumberedListItem.Color.ValueString()},
+		}
+	case m.ToDo != nil:
+		return &notionapi.ToDoBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeToDo),
+			ToDo: notionapi.ToDo{
+				RichText: markdownRichText(m.ToDo.Text.ValueString()),
+				Checked:  m.ToDo.Checked.ValueBool(),
+				Color:    m.ToDo.Color.ValueString(),
+			},
+		}
+	case m.Toggle != nil:
+		return &notionapi.ToggleBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeToggle),
+			Toggle:     notionapi.Toggle{RichText: markdownRichText(m.Toggle.Text.ValueString()), Color: m.Toggle.Color.ValueString()},
+		}
+	case m.Quote != nil:
+		return &notionapi.QuoteBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockQuote),
+			Quote:      notionapi.Quote{RichText: markdownRichText(m.Quote.Text.ValueString()), Color: m.Quote.Color.ValueString()},
+		}
+	case m.Callout != nil:
+		return &notionapi.CalloutBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockCallout),
+			Callout: notionapi.Callout{
+				RichText: markdownRichText(m.Callout.Text.ValueString()),
+				Icon:     expandIcon(m.Callout.Icon),
+				Color:    m.Callout.Color.ValueString(),
+			},
+		}
+	case m.Code != nil:
+		return &notionapi.CodeBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeCode),
+			Code: notionapi.Code{
+				RichText: splitRichText(codeContent(m.Code), nil),
+				Caption:  markdownRichText(m.Code.Caption.ValueString()),
+				Language: m.Code.Language.ValueString(),
+			},
+		}
+	case m.Bookmark != nil:
+		return &notionapi.BookmarkBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeBookmark),
+			Bookmark:   notionapi.Bookmark{URL: m.Bookmark.URL.ValueString(), Caption: markdownRichText(m.Bookmark.Caption.ValueString())},
+		}
+	case m.Embed != nil:
+		return &notionapi.EmbedBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeEmbed),
+			Embed:      notionapi.Embed{URL: m.Embed.URL.ValueString(), Caption: markdownRichText(m.Embed.Caption.ValueString())},
+		}
+	case m.Equation != nil:
+		return &notionapi.EquationBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeEquation),
+			Equation:   notionapi.Equation{Expression: m.Equation.Expression.ValueString()},
+		}
+	case m.Table != nil:
+		return expandTable(m.Table)
+	case m.SyncedBlock != nil:
+		return expandSyncedBlock(m.SyncedBlock)
+	case m.Divider != nil:
+		return &notionapi.DividerBlock{BasicBlock: newBasicBlock(notionapi.BlockTypeDivider)}
+	}
+	return nil
+}
+
+func expandHeading(m *notionHeadingBlockModel) notionapi.Heading {
+	return notionapi.Heading{
+		RichText:     markdownRichText(m.Text.ValueString()),
+		Color:        m.Color.ValueString(),
+		IsToggleable: m.IsToggleable.ValueBool(),
+	}
+}
+
+// blockUpdateRequest returns the request that updates a block to match b, or
+// nil if blocks of its type have nothing to update.
+func blockUpdateRequest(b notionapi.Block) *notionapi.BlockUpdateRequest {
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		return &notionapi.BlockUpdateRequest{Paragraph: &b.Paragraph}
+	case *notionapi.Heading1Block:
+		return &notionapi.BlockUpdateRequest{Heading1: &b.Heading1}
+	case *notionapi.Heading2Block:
+		return &notionapi.BlockUpdateRequest{Heading2: &b.Heading2}
+	case *notionapi.Heading3Block:
+		return &notionapi.BlockUpdateRequest{Heading3: &b.Heading3}
+	case *notionapi.BulletedListItemBlock:
+		return &notionapi.BlockUpdateRequest{BulletedListItem: &b.BulletedListItem}
+	case *notionapi.NumberedListItemBlock:
+		return &notionapi.BlockUpdateRequest{NumberedListItem: &b.NumberedListItem}
+	case *notionapi.ToDoBlock:
+		return &notionapi.BlockUpdateRequest{ToDo: &b.ToDo}
+	case *notionapi.ToggleBlock:
+		return &notionapi.BlockUpdateRequest{Toggle: &b.Toggle}
+	case *notionapi.QuoteBlock:
+		return &notionapi.BlockUpdateRequest{Quote: &b.Quote}
+	case *notionapi.CalloutBlock:
+		return &notionapi.BlockUpdateRequest{Callout: &b.Callout}
+	case *notionapi.CodeBlock:
+		return &notionapi.BlockUpdateRequest{Code: &b.Code}
+	case *notionapi.BookmarkBlock:
+		return &notionapi.BlockUpdateRequest{Bookmark: &b.Bookmark}
+	case *notionapi.EmbedBlock:
+		return &notionapi.BlockUpdateRequest{Embed: &b.Embed}
+	case *notionapi.EquationBlock:
+		return &notionapi.BlockUpdateRequest{Equation: &b.Equation}
+	}
+	return nil
+}
+
+// blockCaption returns the caption configured by a block model, null if the
+// block has none.
+func blockCaption(m *notionBlockResourceModel) types.String {
+	switch {
+	case m.Code != nil:
+		return m.Code.Caption
+	case m.Bookmark != nil:
+		return m.Bookmark.Caption
+	case m.Embed != nil:
+		return m.Embed.Caption
+	}
+	return types.StringNull()
+}
+
+// flattenBlock sets the kind attributes of a block model from a Notion block.
+// Text equivalent to the prior value of m is kept as written. It returns
+// false if the block resource doesn't manage blocks of this type.
+func flattenBlock(b notionapi.Block, m *notionBlockResourceModel) bool {
+	prior := *m
+	*m = notionBlockResourceModel{
+		ID:       m.ID,
+		ParentID: m.ParentID,
+		After:    m.After,
+		Type:     types.StringValue(string(b.GetType())),
+	}
+
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		m.Paragraph = flattenTextBlock(b.Paragraph.RichText, b.Paragraph.Color, prior.Paragraph)
+	case *notionapi.Heading1Block:
+		m.Heading1 = flattenHeadingBlock(b.Heading1, prior.Heading1)
+	case *notionapi.Heading2Block:
+		m.Heading2 = flattenHeadingBlock(b.Heading2, prior.Heading2)
+	case *notionapi.Heading3Block:
+		m.Heading3 = flattenHeadingBlock(b.Heading3, prior.Heading3)
+	case *notionapi.BulletedListItemBlock:
+		m.BulletedListItem = flattenTextBlock(b.BulletedListItem.RichText, b.BulletedListItem.Color, prior.BulletedListItem)
+	case *notionapi.NumberedListItemBlock:
+		m.NumberedListItem = flattenTextBlock(b.NumberedListItem.RichText, b.NumberedListItem.Color, prior.NumberedListItem)
+	case *notionapi.ToDoBlock:
+		var priorText *notionTextBlockModel
+		if prior.ToDo != nil {
+			priorText = &notionTextBlockModel{Text: prior.ToDo.Text}
+		}
+		t := flattenTextBlock(b.ToDo.RichText, b.ToDo.Color, priorText)
+		m.ToDo = &notionToDoBlockModel{Text: t.Text, Color: t.Color, Checked: types.BoolValue(b.ToDo.Checked)}
+	case *notionapi.ToggleBlock:
+		m.Toggle = flattenTextBlock(b.Toggle.RichText, b.Toggle.Color, prior.Toggle)
+	case *notionapi.QuoteBlock:
+		m.Quote = flattenTextBlock(b.Quote.RichText, b.Quote.Color, prior.Quote)
+	case *notionapi.CalloutBlock:
+		var priorText *notionTextBlockModel
+		if prior.Callout != nil {
+			priorText = &notionTextBlockModel{Text: prior.Callout.Text}
+		}
+		t := flattenTextBlock(b.Callout.RichText, b.Callout.Color, priorText)
+		m.Callout = &notionCalloutBlockModel{Text: t.Text, Color: t.Color, Icon: flattenIcon(b.Callout.Icon)}
+		// Notion gives callouts without an icon a default one, which is not
+		// managed unless the configuration sets an icon.
+		if prior.Callout != nil && prior.Callout.Icon == nil {
+			m.Callout.Icon = nil
+		}
+	case *notionapi.CodeBlock:
+		caption := types.StringNull()
+		if prior.Code != nil {
+			caption = prior.Code.Caption
+		}
+		m.Code = &notionCodeBlockModel{
+			Code:         types.StringValue(richTextPlainText(b.Code.RichText)),
+			SourceFile:   types.StringNull(),
+			SourceSHA256: types.StringNull(),
+			Language:     types.StringValue(b.Code.Language),
+			Caption:      flattenInlineMarkdown(b.Code.Caption, caption),
+		}
+		// The code of a block sourced from a file is not kept in state, its
+		// drift is detected by flattenCodeSource.
+		if prior.Code != nil && !prior.Code.SourceFile.IsNull() {
+			m.Code.Code = types.StringNull()
+			m.Code.SourceFile = prior.Code.SourceFile
+			m.Code.SourceSHA256 = prior.Code.SourceSHA256
+		}
+	case *notionapi.BookmarkBlock:
+		m.Bookmark = flattenLinkBlock(b.Bookmark.URL, b.Bookmark.Caption, prior.Bookmark)
+	case *notionapi.EmbedBlock:
+		m.Embed = flattenLinkBlock(b.Embed.URL, b.Embed.Caption, prior.Embed)
+	case *notionapi.EquationBlock:
+		m.Equation = &notionEquationBlockModel{Expression: types.StringValue(b.Equation.Expression)}
+	case *notionapi.TableBlock:
+		m.Table = flattenTable(b.Table, prior.Table)
+	case *notionapi.SyncedBlock:
+		m.SyncedBlock = flattenSyncedBlock(b.SyncedBlock, prior.SyncedBlock)
+	case *notionapi.DividerBlock:
+		m.Divider = &notionDividerBlockModel{}
+	default:
+		return false
+	}
+	return true
+}
+
+func flattenTextBlock(rt []notionapi.RichText, color string, prior *notionTextBlockModel) *notionTextBlockModel {
+	text := types.StringValue("")
+	if prior != nil {
+		text = prior.Text
+	}
+	if color == "" {
+		color = string(notionapi.ColorDefault)
+	}
+	return &notionTextBlockModel{
+		Text:  flattenInlineMarkdown(rt, text),
+		Color: types.StringValue(color),
+	}
+}
+
+func flattenHeadingBlock(h notionapi.Heading, prior *notionHeadingBlockModel) *notionHeadingBlockModel {
+	var priorText *notionTextBlockModel
+	if prior != nil {
+		priorText = &notionTextBlockModel{Text: prior.Text}
+	}
+	t := flattenTextBlock(h.RichText, h.Color, priorText)
+	return &notionHeadingBlockModel{Text: t.Text, Color: t.Color, IsToggleable: types.BoolValue(h.IsToggleable)}
+}
+
+func flattenLinkBlock(url string, caption []notionapi.RichText, prior *notionLinkBlockModel) *notionLinkBlockModel {
+	priorCaption := types.StringNull()
+	if prior != nil {
+		priorCaption = prior.Caption
+	}
+	return &notionLinkBlockModel{
+		URL:     types.StringValue(url),
+		Caption: flattenInlineMarkdown(caption, priorCaption),
+	}
+}
+
+// flattenInlineMarkdown returns rich text as inline Markdown. The prior value
+// is kept if it produces the same rich text, and empty text is null unless
+// the prior value is set.
+func flattenInlineMarkdown(rt []notionapi.RichText, prior types.String) types.String {
+	md := richTextToMarkdown(rt)
+	if !prior.IsNull() && !prior.IsUnknown() && richTextToMarkdown(markdownRichText(prior.ValueString())) == md {
+		return prior
+	}
+	if md == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(md)
+}
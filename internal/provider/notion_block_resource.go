@@ -0,0 +1,673 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jomei/notionapi"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &notionBlockResource{}
+	_ resource.ResourceWithConfigure      = &notionBlockResource{}
+	_ resource.ResourceWithValidateConfig = &notionBlockResource{}
+	_ resource.ResourceWithModifyPlan     = &notionBlockResource{}
+	_ resource.ResourceWithImportState    = &notionBlockResource{}
+)
+
+type notionBlockResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	ParentID types.String `tfsdk:"parent_id"`
+	After    types.String `tfsdk:"after"`
+	Type     types.String `tfsdk:"type"`
+
+	Paragraph        *notionTextBlockModel     `tfsdk:"paragraph"`
+	Heading1         *notionHeadingBlockModel  `tfsdk:"heading_1"`
+	Heading2         *notionHeadingBlockModel  `tfsdk:"heading_2"`
+	Heading3         *notionHeadingBlockModel  `tfsdk:"heading_3"`
+	BulletedListItem *notionTextBlockModel     `tfsdk:"bulleted_list_item"`
+	NumberedListItem *notionTextBlockModel     `tfsdk:"numbered_list_item"`
+	ToDo             *notionToDoBlockModel     `tfsdk:"to_do"`
+	Toggle           *notionTextBlockModel     `tfsdk:"toggle"`
+	Quote            *notionTextBlockModel     `tfsdk:"quote"`
+	Callout          *notionCalloutBlockModel  `tfsdk:"callout"`
+	Code             *notionCodeBlockModel     `tfsdk:"code"`
+	Bookmark         *notionLinkBlockModel     `tfsdk:"bookmark"`
+	Embed            *notionLinkBlockModel     `tfsdk:"embed"`
+	Equation         *notionEquationBlockModel `tfsdk:"equation"`
+	Table            *notionTableBlockModel    `tfsdk:"table"`
+	SyncedBlock      *notionSyncedBlockModel   `tfsdk:"synced_block"`
+	Divider          *notionDividerBlockModel  `tfsdk:"divider"`
+}
+
+// NewNotionBlockResource is a helper function to simplify the provider implementation.
+func NewNotionBlockResource() resource.Resource {
+	return &notionBlockResource{}
+}
+
+// notionBlockResource is the resource implementation.
+type notionBlockResource struct {
+	client *notionClient
+}
+
+// Metadata returns the resource type name.
+func (r *notionBlockResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notion_block"
+}
+
+// Schema defines the schema for the resource.
+func (r *notionBlockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attrs := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Notion block id",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"parent_id": schema.StringAttribute{
+			MarkdownDescription: "The id of the page or block the block is appended to.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"after": schema.StringAttribute{
+			MarkdownDescription: "The id of the sibling block to insert the block after. If unset, the block is appended after the parent's last block. " +
+				"It is only used when the block is created; changing it re-creates the block.",
+			Optional: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The type of the block, the name of the attribute that configures it. Changing the type re-creates the block.",
+			Computed:            true,
+		},
+	}
+	for k, v := range blockKindAttributes() {
+		attrs[k] = v
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A single block of content, appended to a page or block that is otherwise not managed, such as a status banner on a shared page. " +
+			"Exactly one of the attributes named after a block type must be set. Destroying the resource deletes the block.",
+		Attributes: attrs,
+	}
+}
+
+// ValidateConfig validates that exactly one kind of block is configured.
+func (r *notionBlockResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if blockModelUnknown(req.Config.Raw) {
+		// Validated once the values are known.
+		return
+	}
+
+	var config notionBlockResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ts := blockTypes(&config); len(ts) != 1 {
+		names := make([]string, 0, len(ts))
+		for _, t := range ts {
+			names = append(names, string(t))
+		}
+		resp.Diagnostics.AddError(
+			"Invalid block",
+			fmt.Sprintf("Exactly one block type attribute must be set, got %d: %s.", len(ts), strings.Join(names, ", ")),
+		)
+	}
+}
+
+// ModifyPlan sets the type of the block, and re-creates the block when it
+// changes as Notion can't change the type of a block.
+func (r *notionBlockResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to do on destroy.
+		return
+	}
+	if blockModelUnknown(req.Plan.Raw) {
+		// The computed values stay unknown until the values they derive
+		// from are known.
+		return
+	}
+
+	var plan notionBlockResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ts := blockTypes(&plan)
+	if len(ts) != 1 {
+		return
+	}
+	diags = resp.Plan.SetAttribute(ctx, path.Root("type"), string(ts[0]))
+	resp.Diagnostics.Append(diags...)
+
+	// The checksum of a source file is planned so that changes to the file
+	// show as a change of the block.
+	if plan.Code != nil && !plan.Code.SourceFile.IsUnknown() {
+		sum := types.StringNull()
+		if !plan.Code.SourceFile.IsNull() {
+			s, err := loadCodeSource(plan.Code)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("code").AtName("source_file"),
+					"Failed to read source file",
+					fmt.Sprintf("Failed to read the code of the block: %s", err),
+				)
+				return
+			}
+			sum = types.StringValue(s)
+		}
+		diags = resp.Plan.SetAttribute(ctx, path.Root("code").AtName("source_sha256"), sum)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Likewise for the cells of a table, whose width can't change.
+	tableKnown := false
+	if plan.Table != nil {
+		ok, err := loadTableGrid(plan.Table)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table"),
+				"Invalid table",
+				fmt.Sprintf("Failed to build the rows of the table: %s", err),
+			)
+			return
+		}
+		if ok {
+			tableKnown = true
+			setTableComputed(plan.Table)
+			diags = resp.Plan.SetAttribute(ctx, path.Root("table").AtName("table_width"), plan.Table.TableWidth)
+			resp.Diagnostics.Append(diags...)
+			diags = resp.Plan.SetAttribute(ctx, path.Root("table").AtName("content_sha256"), plan.Table.ContentSHA256)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planSyncedCopies(ctx, &plan, nil, resp)...)
+		return
+	}
+	var state notionBlockResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.planSyncedCopies(ctx, &plan, state.SyncedBlock, resp)...)
+	if state.Type.ValueString() != string(ts[0]) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type"))
+	}
+	if tableKnown && state.Table != nil && !state.Table.TableWidth.Equal(plan.Table.TableWidth) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("table").AtName("table_width"))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *notionBlockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan notionBlockResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(loadBlockSource(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SyncedBlock != nil && !plan.SyncedBlock.SyncedFrom.IsNull() {
+		if err := checkSyncedOriginal(ctx, r.client, notionapi.BlockID(plan.SyncedBlock.SyncedFrom.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("synced_block").AtName("synced_from"),
+				"Failed to create block",
+				fmt.Sprintf("Failed to copy synced block: %s", err),
+			)
+			return
+		}
+	}
+
+	created, err := appendBlockTree(ctx, r.client,
+		notionapi.BlockID(plan.ParentID.ValueString()),
+		notionapi.BlockID(plan.After.ValueString()),
+		[]notionapi.Block{expandBlock(&plan)},
+	)
+	if err == nil && len(created) == 0 {
+		err = fmt.Errorf("Notion returned no block")
+	}
+	if err != nil && len(created) == 0 {
+		tflog.Debug(ctx, "Failed to create block")
+		resp.Diagnostics.AddError(
+			"Failed to create block",
+			fmt.Sprintf("Failed to append block: %s", err),
+		)
+		return
+	}
+
+	block := created[0]
+	plan.ID = types.StringValue(block.GetID().String())
+	plan.Type = types.StringValue(string(block.GetType()))
+	setBlockComputed(&plan, block)
+	if v := codeSourceHash(&plan, block); v != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, notionCodeHashKey, v)...)
+	}
+	if plan.SyncedBlock != nil {
+		plan.SyncedBlock.PageID = types.StringNull()
+		if pageID, pageErr := blockPageID(ctx, r.client, block); pageErr == nil {
+			plan.SyncedBlock.PageID = types.StringValue(pageID)
+		} else if err == nil {
+			err = fmt.Errorf("failed to find the page of the block: %w", pageErr)
+		}
+		if copiesErr := applySyncedCopies(ctx, r.client, block.GetID(), plan.SyncedBlock, map[string]string{}); copiesErr != nil && err == nil {
+			err = copiesErr
+		}
+	}
+
+	if err != nil {
+		// The block exists but some of its children, such as the rows of a
+		// long table, or the copies of a synced block, are missing.
+		setPartiallyCreated(ctx, resp, plan, "Failed to create block", fmt.Sprintf("Failed to complete the block: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *notionBlockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state notionBlockResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	block, err := r.client.Block.Get(ctx, notionapi.BlockID(state.ID.ValueString()))
+	if err != nil {
+		if isNotionNotFound(err) {
+			tflog.Warn(ctx, "Block not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Debug(ctx, "Failed to read block")
+		resp.Diagnostics.AddError(
+			"Failed to read block",
+			fmt.Sprintf("Failed to get block: %s", err),
+		)
+		return
+	}
+	if block.GetArchived() {
+		tflog.Warn(ctx, "Block is deleted, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if (block.GetType() == notionapi.BlockTypeTableBlock || isSyncedOriginal(block)) && block.GetHasChildren() {
+		children, err := getBlockChildren(ctx, r.client, block.GetID())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read block",
+				fmt.Sprintf("Failed to get the children of the block: %s", err),
+			)
+			return
+		}
+		setBlockChildren(block, children)
+	}
+
+	state.ID = types.StringValue(block.GetID().String())
+	if p := block.GetParent(); p != nil {
+		_, parentID := flattenParent(*p)
+		if !notionIDEqual(state.ParentID.ValueString(), parentID) {
+			state.ParentID = types.StringValue(parentID)
+		}
+	}
+	if !flattenBlock(block, &state) {
+		resp.Diagnostics.AddError(
+			"Unsupported block type",
+			fmt.Sprintf("Block %s is a %s block, which the block resource doesn't manage.", state.ID.ValueString(), block.GetType()),
+		)
+		return
+	}
+	recorded, diags := req.Private.GetKey(ctx, notionCodeHashKey)
+	resp.Diagnostics.Append(diags...)
+	flattenCodeSource(&state, block, recorded)
+
+	if state.SyncedBlock != nil {
+		pageID, err := blockPageID(ctx, r.client, block)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read block",
+				fmt.Sprintf("Failed to find the page of the block: %s", err),
+			)
+			return
+		}
+		state.SyncedBlock.PageID = types.StringValue(pageID)
+	}
+	if state.SyncedBlock != nil && state.SyncedBlock.SyncedFrom.IsNull() {
+		copies, diags := syncedCopies(ctx, state.SyncedBlock)
+		resp.Diagnostics.Append(diags...)
+		pages, err := readSyncedCopies(ctx, r.client, copies)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read block",
+				fmt.Sprintf("Failed to read the copies of the synced block: %s", err),
+			)
+			return
+		}
+		setSyncedCopies(state.SyncedBlock, copies, pages)
+	}
+	if state.SyncedBlock != nil && !state.SyncedBlock.SyncedFrom.IsNull() {
+		exists, err := syncedOriginalExists(ctx, r.client, notionapi.BlockID(state.SyncedBlock.SyncedFrom.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read block",
+				fmt.Sprintf("Failed to get the original synced block: %s", err),
+			)
+			return
+		}
+		if !exists {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("synced_block").AtName("synced_from"),
+				"Original synced block is deleted",
+				fmt.Sprintf("Block %s is a copy of synced block %s, which is deleted, so the copy shows nothing. "+
+					"Set synced_from to another original, or remove the block.", state.ID.ValueString(), state.SyncedBlock.SyncedFrom.ValueString()),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *notionBlockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state notionBlockResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(loadBlockSource(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := notionapi.BlockID(state.ID.ValueString())
+	if updateReq := blockUpdateRequest(expandBlock(&plan)); updateReq != nil {
+		block, err := r.client.Block.Update(ctx, id, updateReq)
+		// Empty captions are left out of update requests, so removing a
+		// caption takes another one.
+		if err == nil && blockCaption(&plan).IsNull() && !blockCaption(&state).IsNull() {
+			err = clearBlockCaption(ctx, r.client, id, block.GetType())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to update block",
+				fmt.Sprintf("Failed to update block: %s", err),
+			)
+			return
+		}
+		setBlockComputed(&plan, block)
+		if v := codeSourceHash(&plan, block); v != nil {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, notionCodeHashKey, v)...)
+		}
+	}
+
+	if plan.Table != nil {
+		if err := updateTable(ctx, r.client, id, plan.Table, state.Table); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to update block",
+				fmt.Sprintf("Failed to update table: %s", err),
+			)
+			return
+		}
+	}
+
+	if plan.SyncedBlock != nil && !plan.SyncedBlock.ContentMarkdown.IsNull() && !plan.SyncedBlock.ContentMarkdown.Equal(state.SyncedBlock.ContentMarkdown) {
+		if _, err := syncBlockChildren(ctx, r.client, id, markdownToBlocks(plan.SyncedBlock.ContentMarkdown.ValueString())); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to update block",
+				fmt.Sprintf("Failed to update the content of the synced block: %s", err),
+			)
+			return
+		}
+	}
+
+	plan.ID = state.ID
+
+	if plan.SyncedBlock != nil && plan.SyncedBlock.SyncedFrom.IsNull() {
+		copies, diags := syncedCopies(ctx, state.SyncedBlock)
+		resp.Diagnostics.Append(diags...)
+		if err := applySyncedCopies(ctx, r.client, id, plan.SyncedBlock, copies); err != nil {
+			// Keep the copies that were made in state.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			resp.Diagnostics.AddError(
+				"Failed to update block",
+				fmt.Sprintf("Failed to update the copies of the synced block: %s", err),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *notionBlockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state notionBlockResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The copies of a synced block are deleted first, they would show
+	// nothing without their original.
+	if state.SyncedBlock != nil && state.SyncedBlock.SyncedFrom.IsNull() {
+		copies, diags := syncedCopies(ctx, state.SyncedBlock)
+		resp.Diagnostics.Append(diags...)
+		if err := deleteSyncedCopies(ctx, r.client, copies, nil); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to delete block",
+				fmt.Sprintf("Failed to delete the copies of the synced block: %s", err),
+			)
+			return
+		}
+	}
+
+	id := notionapi.BlockID(state.ID.ValueString())
+	_, err := r.client.Block.Delete(ctx, id)
+	// A block deleted along with another one, such as its parent, or the
+	// original of a synced block copy, can fail to be deleted again.
+	if err != nil && !isNotionNotFound(err) && !blockDeleted(ctx, r.client, id) {
+		resp.Diagnostics.AddError(
+			"Failed to delete block",
+			fmt.Sprintf("Failed to delete block: %s", err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing block by its id.
+func (r *notionBlockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseNotionID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected a block id: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *notionBlockResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*notionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// planSyncedCopies plans the copies of a synced block, given the synced
+// block in state, if any.
+func (r *notionBlockResource) planSyncedCopies(ctx context.Context, plan *notionBlockResourceModel, state *notionSyncedBlockModel, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	if plan.SyncedBlock == nil {
+		return nil
+	}
+	copies, pages, diags := planSyncedCopies(ctx, plan.SyncedBlock, state)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("synced_block").AtName("copies"), copies)...)
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("synced_block").AtName("copy_page_ids"), pages)...)
+	return diags
+}
+
+// setBlockComputed fills the computed attributes of a planned block from the
+// block Notion returned.
+func setBlockComputed(plan *notionBlockResourceModel, block notionapi.Block) {
+	if plan.Callout == nil {
+		return
+	}
+	var icon *notionapi.Icon
+	if b, ok := block.(*notionapi.CalloutBlock); ok {
+		icon = b.Callout.Icon
+	}
+	setIconCoverComputed(plan.Callout.Icon, nil, icon, nil)
+}
+
+// loadBlockSource reads the source file of a planned code block and sets its
+// checksum, and builds the rows of a planned table. It fails if the content
+// changed since the plan.
+func loadBlockSource(plan *notionBlockResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Table != nil {
+		return loadTableSource(plan.Table)
+	}
+	if plan.Code == nil || plan.Code.SourceFile.IsNull() {
+		return diags
+	}
+	sum, err := loadCodeSource(plan.Code)
+	if err == nil && !plan.Code.SourceSHA256.IsUnknown() && sum != plan.Code.SourceSHA256.ValueString() {
+		err = fmt.Errorf("%s changed after the plan was made", plan.Code.SourceFile.ValueString())
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("code").AtName("source_file"),
+			"Failed to read source file",
+			fmt.Sprintf("Failed to read the code of the block: %s", err),
+		)
+		return diags
+	}
+	plan.Code.SourceSHA256 = types.StringValue(sum)
+	return diags
+}
+
+func loadTableSource(plan *notionTableBlockModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	planned := plan.ContentSHA256
+	ok, err := loadTableGrid(plan)
+	if err == nil && !ok {
+		err = fmt.Errorf("the rows are not known")
+	}
+	if err == nil {
+		setTableComputed(plan)
+		if !planned.IsUnknown() && !planned.Equal(plan.ContentSHA256) {
+			err = fmt.Errorf("%s changed after the plan was made", plan.SourceFile.ValueString())
+		}
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("table"),
+			"Invalid table",
+			fmt.Sprintf("Failed to build the rows of the table: %s", err),
+		)
+	}
+	return diags
+}
+
+// updateTable updates the header flags and the rows of a table.
+func updateTable(ctx context.Context, client *notionClient, id notionapi.BlockID, plan, state *notionTableBlockModel) error {
+	if !plan.HasColumnHeader.Equal(state.HasColumnHeader) || !plan.HasRowHeader.Equal(state.HasRowHeader) {
+		if err := updateTableHeaders(ctx, client, id, plan.HasColumnHeader.ValueBool(), plan.HasRowHeader.ValueBool()); err != nil {
+			return err
+		}
+	}
+	if plan.ContentSHA256.Equal(state.ContentSHA256) {
+		return nil
+	}
+	return syncTableRows(ctx, client, id, plan.Grid)
+}
+
+// blockDeleted reports whether a block is known to be deleted.
+func blockDeleted(ctx context.Context, client *notionClient, id notionapi.BlockID) bool {
+	b, err := client.Block.Get(ctx, id)
+	if err != nil {
+		return isNotionNotFound(err)
+	}
+	return b.GetArchived()
+}
+
+// blockModelUnknown reports whether a raw block config or plan has values the
+// block model can't hold yet. The collections of synced blocks are framework
+// values, so only the synced block itself must be known.
+func blockModelUnknown(raw tftypes.Value) bool {
+	var kinds []string
+	for k := range blockKindAttributes() {
+		if k != string(notionapi.BlockTypeSyncedBlock) {
+			kinds = append(kinds, k)
+		}
+	}
+	if unknownModelValues(raw, kinds...) {
+		return true
+	}
+	v, _, err := tftypes.WalkAttributePath(raw, tftypes.NewAttributePath().WithAttributeName(string(notionapi.BlockTypeSyncedBlock)))
+	if err != nil {
+		return false
+	}
+	synced, ok := v.(tftypes.Value)
+	return ok && !synced.IsKnown()
+}
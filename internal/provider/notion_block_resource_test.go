@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionBlockResource(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccNotionBlockResourceConfig(parentID, `callout = { text = "Deploys are **frozen**", icon = { emoji = "🚧" } }`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttrSet("yoloexp_notion_block.test", "id"),
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "type", "callout"),
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "callout.color", "default"),
+				),
+			},
+			{
+				ResourceName:            "yoloexp_notion_block.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"after"},
+			},
+			{
+				Config: testAccNotionBlockResourceConfig(parentID, `callout = { text = "Deploys are open", color = "green_background", icon = { emoji = "✅" } }`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "callout.text", "Deploys are open"),
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "callout.icon.emoji", "✅"),
+				),
+			},
+			{
+				Config: testAccNotionBlockResourceConfig(parentID, `heading_2 = { text = "Status" }`),
+				Check:  tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "type", "heading_2"),
+			},
+		},
+	})
+}
+
+func testAccNotionBlockResourceConfig(parentID, block string) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_page" "test" {
+  parent_id = %[1]q
+  title     = "Acceptance test blocks"
+}
+
+resource "yoloexp_notion_block" "test" {
+  parent_id = yoloexp_notion_page.test.id
+  %[2]s
+}
+`, parentID, block)
+}
+
+func TestNotionBlockResourceModel(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewNotionBlockResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	want := notionBlockResourceModel{
+		ID:       types.StringValue("b"),
+		ParentID: types.StringValue("p"),
+		After:    types.StringNull(),
+		Type:     types.StringValue("divider"),
+		Divider:  &notionDividerBlockModel{},
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &want); diags.HasError() {
+		t.Fatalf("State.Set() = %v", diags)
+	}
+	var got notionBlockResourceModel
+	if diags := state.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("State.Get() = %v", diags)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("State.Get() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExpandBlock(t *testing.T) {
+	m := notionBlockResourceModel{
+		Code: &notionCodeBlockModel{
+			Code:     types.StringValue("terraform apply"),
+			Language: types.StringValue("shell"),
+			Caption:  types.StringValue("Run **once**"),
+		},
+	}
+	b := expandBlock(&m)
+	got, err := json.Marshal(blockUpdateRequest(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"code":{"rich_text":[{"type":"text","text":{"content":"terraform apply"}}],"caption":[{"type":"text","text":{"content":"Run "},"annotations":{"bold":false,"italic":false,"strikethrough":false,"underline":false,"code":false,"color":"default"}},{"type":"text","text":{"content":"once"},"annotations":{"bold":true,"italic":false,"strikethrough":false,"underline":false,"code":false,"color":"default"}}],"language":"shell"}}`
+	if string(got) != want {
+		t.Errorf("blockUpdateRequest() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Equivalent Markdown is kept as written.
+	m.Code.Caption = types.StringValue("Run __once__")
+	if !flattenBlock(b, &m) || m.Code.Caption.ValueString() != "Run __once__" || m.Type.ValueString() != string(notionapi.BlockTypeCode) {
+		t.Errorf("flattenBlock() = %+v", m.Code)
+	}
+
+	emoji := notionapi.Emoji("💡")
+	callout := &notionapi.CalloutBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockCallout),
+		Callout:    notionapi.Callout{RichText: plainRichText("Note"), Icon: &notionapi.Icon{Type: "emoji", Emoji: &emoji}},
+	}
+	m = notionBlockResourceModel{Callout: &notionCalloutBlockModel{Text: types.StringValue("Note")}}
+	if !flattenBlock(callout, &m) || m.Callout.Icon != nil {
+		t.Errorf("flattenBlock() set the unmanaged callout icon: %+v", m.Callout.Icon)
+	}
+	if flattenBlock(&notionapi.TableOfContentsBlock{BasicBlock: newBasicBlock(notionapi.BlockTypeTableOfContents)}, &m) {
+		t.Error("flattenBlock() accepted a table of contents")
+	}
+}
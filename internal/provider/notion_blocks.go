@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/jomei/notionapi"
+)
+
+// notionAppendLimit is the maximum number of blocks a single request can
+// append, and the maximum number of children of each appended block.
+const notionAppendLimit = 100
+
+// notionNestingLimit is the number of levels of children the blocks appended
+// by a single request can have.
+const notionNestingLimit = 2
+
+// getBlockChildren returns the children of a block, following pagination,
+// with the children of nested blocks attached to their parents. The content
+// of child pages and databases is not fetched.
+func getBlockChildren(ctx context.Context, client *notionClient, id notionapi.BlockID) ([]notionapi.Block, error) {
+	var blocks []notionapi.Block
+	pagination := &notionapi.Pagination{PageSize: notionAppendLimit}
+	for {
+		resp, err := client.Block.GetChildren(ctx, id, pagination)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, resp.Results...)
+		if !resp.HasMore {
+			break
+		}
+		pagination.StartCursor = notionapi.Cursor(resp.NextCursor)
+	}
+
+	for _, b := range blocks {
+		if !b.GetHasChildren() || isChildObjectBlock(b) {
+			continue
+		}
+		children, err := getBlockChildren(ctx, client, b.GetID())
+		if err != nil {
+			return nil, err
+		}
+		setBlockChildren(b, children)
+	}
+	return blocks, nil
+}
+
+// isChildObjectBlock reports whether a block is a child page or database,
+// which are objects of their own rather than content of their parent.
+func isChildObjectBlock(b notionapi.Block) bool {
+	t := b.GetType()
+	return t == notionapi.BlockTypeChildPage || t == notionapi.BlockTypeChildDatabase
+}
+
+// setBlockChildren attaches children to a block that can have them.
+func setBlockChildren(b notionapi.Block, children []notionapi.Block) {
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		b.Paragraph.Children = children
+	case *notionapi.Heading1Block:
+		b.Heading1.Children = children
+	case *notionapi.Heading2Block:
+		b.Heading2.Children = children
+	case *notionapi.Heading3Block:
+		b.Heading3.Children = children
+	case *notionapi.BulletedListItemBlock:
+		b.BulletedListItem.Children = children
+	case *notionapi.NumberedListItemBlock:
+		b.NumberedListItem.Children = children
+	case *notionapi.ToDoBlock:
+		b.ToDo.Children = children
+	case *notionapi.ToggleBlock:
+		b.Toggle.Children = children
+	case *notionapi.QuoteBlock:
+		b.Quote.Children = children
+	case *notionapi.CalloutBlock:
+		b.Callout.Children = children
+	case *notionapi.TableBlock:
+		b.Table.Children = children
+	case *notionapi.ColumnListBlock:
+		b.ColumnList.Children = children
+	case *notionapi.ColumnBlock:
+		b.Column.Children = children
+	case *notionapi.SyncedBlock:
+		b.SyncedBlock.Children = children
+	case *notionapi.TemplateBlock:
+		b.Template.Children = children
+	}
+}
+
+// blockChildren returns the children of a block.
+func blockChildren(b notionapi.Block) []notionapi.Block {
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		return b.Paragraph.Children
+	case *notionapi.Heading1Block:
+		return b.Heading1.Children
+	case *notionapi.Heading2Block:
+		return b.Heading2.Children
+	case *notionapi.Heading3Block:
+		return b.Heading3.Children
+	case *notionapi.BulletedListItemBlock:
+		return b.BulletedListItem.Children
+	case *notionapi.NumberedListItemBlock:
+		return b.NumberedListItem.Children
+	case *notionapi.ToDoBlock:
+		return b.ToDo.Children
+	case *notionapi.ToggleBlock:
+		return b.Toggle.Children
+	case *notionapi.QuoteBlock:
+		return b.Quote.Children
+	case *notionapi.CalloutBlock:
+		return b.Callout.Children
+	case *notionapi.TableBlock:
+		return b.Table.Children
+	case *notionapi.ColumnListBlock:
+		return b.ColumnList.Children
+	case *notionapi.ColumnBlock:
+		return b.Column.Children
+	case *notionapi.SyncedBlock:
+		return b.SyncedBlock.Children
+	case *notionapi.TemplateBlock:
+		return b.Template.Children
+	}
+	return nil
+}
+
+// copyBlock returns a shallow copy of a block, whose children can be set
+// without changing b. Blocks of types that can't have children are returned
+// as they are, setBlockChildren leaves them unchanged.
+func copyBlock(b notionapi.Block) notionapi.Block {
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		c := *b
+		return &c
+	case *notionapi.Heading1Block:
+		c := *b
+		return &c
+	case *notionapi.Heading2Block:
+		c := *b
+		return &c
+	case *notionapi.Heading3Block:
+		c := *b
+		return &c
+	case *notionapi.BulletedListItemBlock:
+		c := *b
+		return &c
+	case *notionapi.NumberedListItemBlock:
+		c := *b
+		return &c
+	case *notionapi.ToDoBlock:
+		c := *b
+		return &c
+	case *notionapi.ToggleBlock:
+		c := *b
+		return &c
+	case *notionapi.QuoteBlock:
+		c := *b
+		return &c
+	case *notionapi.CalloutBlock:
+		c := *b
+		return &c
+	case *notionapi.TableBlock:
+		c := *b
+		return &c
+	case *notionapi.ColumnListBlock:
+		c := *b
+		return &c
+	case *notionapi.ColumnBlock:
+		c := *b
+		return &c
+	case *notionapi.SyncedBlock:
+		c := *b
+		return &c
+	case *notionapi.TemplateBlock:
+		c := *b
+		return &c
+	}
+	return b
+}
+
+// inlineBlock returns a copy of a block with the children that are sent in
+// the request that creates it, given the levels of children the request can
+// still hold. Only the children Notion requires at creation are sent: the
+// rows of a table, and the columns of a column layout with their first
+// blocks. The other children are appended once their parent exists.
+func inlineBlock(b notionapi.Block, levels int) notionapi.Block {
+	c := copyBlock(b)
+	children := blockChildren(b)
+	switch t := b.GetType(); {
+	case levels > 0 && t == notionapi.BlockTypeTableBlock:
+		setBlockChildren(c, children[:min(len(children), notionAppendLimit)])
+	case levels > 0 && (t == notionapi.BlockTypeColumnList || t == notionapi.BlockTypeColumn):
+		inline := make([]notionapi.Block, 0, min(len(children), notionAppendLimit))
+		for _, child := range children[:min(len(children), notionAppendLimit)] {
+			inline = append(inline, inlineBlock(child, levels-1))
+		}
+		setBlockChildren(c, inline)
+	default:
+		setBlockChildren(c, nil)
+	}
+	return c
+}
+
+// appendBlockTree appends blocks and all their descendants to a parent block
+// or page, after the block with id after or at the end if after is empty.
+// Notion limits how many children and levels of children a request can
+// append, so each block is created with the children it requires and the
+// others are appended level by level. It returns the created blocks with
+// their created descendants attached, which may be partial on error.
+func appendBlockTree(ctx context.Context, client *notionClient, parent, after notionapi.BlockID, blocks []notionapi.Block) ([]notionapi.Block, error) {
+	sent := make([]notionapi.Block, len(blocks))
+	for i, b := range blocks {
+		sent[i] = inlineBlock(b, notionNestingLimit)
+	}
+	created, err := appendBlockChildren(ctx, client, parent, after, sent)
+	if err != nil {
+		return created, err
+	}
+	for i, c := range created {
+		if err := appendRemainingChildren(ctx, client, c, blocks[i], sent[i]); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// setPartiallyCreated saves the state of a resource whose object was created
+// but not completed, such as a page or block whose children were only partly
+// appended, and fails the creation. The object is kept in state so that it is
+// not orphaned; as creation failed, Terraform replaces it on the next apply.
+func setPartiallyCreated(ctx context.Context, resp *resource.CreateResponse, model any, summary, detail string) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+	resp.Diagnostics.AddError(summary, detail)
+}
+
+// appendRemainingChildren appends the children of the desired block that
+// were not sent with the request that created block c, and attaches all its
+// children to c.
+func appendRemainingChildren(ctx context.Context, client *notionClient, c, desired, sent notionapi.Block) error {
+	want := blockChildren(desired)
+	if len(want) == 0 {
+		return nil
+	}
+	inline := blockChildren(sent)
+
+	var have []notionapi.Block
+	if len(inline) > 0 {
+		// Created blocks come without their children, unless they were
+		// fetched with their parent.
+		children := blockChildren(c)
+		if len(children) != len(inline) {
+			var err error
+			if children, err = getBlockChildren(ctx, client, c.GetID()); err != nil {
+				return err
+			}
+		}
+		if len(children) != len(inline) {
+			return fmt.Errorf("block %s has %d children, expected %d", c.GetID(), len(children), len(inline))
+		}
+		for i, child := range children {
+			if err := appendRemainingChildren(ctx, client, child, want[i], inline[i]); err != nil {
+				return err
+			}
+		}
+		have = children
+	}
+
+	var after notionapi.BlockID
+	if len(have) > 0 {
+		after = have[len(have)-1].GetID()
+	}
+	rest, err := appendBlockTree(ctx, client, c.GetID(), after, want[len(inline):])
+	setBlockChildren(c, append(have, rest...))
+	return err
+}
+
+// appendBlockChildren appends blocks to a parent block or page, after the
+// block with id after or at the end if after is empty, in as many requests
+// as needed. It returns the created blocks. The blocks are sent with their
+// children as they are; appendBlockTree splits deeper trees.
+func appendBlockChildren(ctx context.Context, client *notionClient, parent, after notionapi.BlockID, blocks []notionapi.Block) ([]notionapi.Block, error) {
+	var created []notionapi.Block
+	for len(blocks) > 0 {
+		n := min(len(blocks), notionAppendLimit)
+		resp, err := client.Block.AppendChildren(ctx, parent, &notionapi.AppendBlockChildrenRequest{
+			After:    after,
+			Children: blocks[:n],
+		})
+		if err != nil {
+			return created, err
+		}
+		blocks = blocks[n:]
+		created = append(created, resp.Results...)
+		if len(resp.Results) > 0 {
+			after = resp.Results[len(resp.Results)-1].GetID()
+		}
+	}
+	return created, nil
+}
+
+// syncBlockChildren makes the children of a parent block or page match the
+// desired blocks. Blocks are compared by their Markdown; the blocks common to
+// the start and the end of both lists are kept and the ones in between are
+// replaced in place. Child pages and databases are never touched. It returns
+// the resulting children, without child pages and databases.
+func syncBlockChildren(ctx context.Context, client *notionClient, parent notionapi.BlockID, desired []notionapi.Block) ([]notionapi.Block, error) {
+	children, err := getBlockChildren(ctx, client, parent)
+	if err != nil {
+		return nil, err
+	}
+	var current []notionapi.Block
+	for _, b := range children {
+		if !isChildObjectBlock(b) {
+			current = append(current, b)
+		}
+	}
+
+	prefix := 0
+	for prefix < len(current) && prefix < len(desired) && blockMarkdownEqual(current[prefix], desired[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(current)-prefix && suffix < len(desired)-prefix &&
+		blockMarkdownEqual(current[len(current)-1-suffix], desired[len(desired)-1-suffix]) {
+		suffix++
+	}
+	after, suffix := syncAnchor(children, current, prefix, suffix, len(desired)-prefix-suffix > 0)
+
+	// The new blocks are inserted before the replaced ones are deleted, as
+	// the first replaced block may be the one they are inserted after.
+	created, err := appendBlockTree(ctx, client, parent, after, desired[prefix:len(desired)-suffix])
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range current[prefix : len(current)-suffix] {
+		if _, err := client.Block.Delete(ctx, b.GetID()); err != nil && !isNotionNotFound(err) {
+			return nil, err
+		}
+	}
+
+	result := append(current[:prefix:prefix], created...)
+	return append(result, current[len(current)-suffix:]...), nil
+}
+
+// syncAnchor returns the block after which syncBlockChildren inserts the
+// blocks replacing current[prefix:len(current)-suffix], so that they take
+// the place of the replaced blocks among the children, child pages and
+// databases included. Blocks can only be inserted after another block: at
+// the start of the children, they are inserted after the first replaced
+// block, and if no block is replaced, the first kept block is replaced too.
+// It returns the number of blocks kept at the end, which is one less in the
+// latter case.
+func syncAnchor(children, current []notionapi.Block, prefix, suffix int, inserting bool) (notionapi.BlockID, int) {
+	preceding := func(b notionapi.Block) notionapi.BlockID {
+		for i, c := range children {
+			if c.GetID() == b.GetID() && i > 0 {
+				return children[i-1].GetID()
+			}
+		}
+		return ""
+	}
+
+	switch {
+	case prefix < len(current)-suffix:
+		if after := preceding(current[prefix]); after != "" {
+			return after, suffix
+		}
+		return current[prefix].GetID(), suffix
+	case !inserting:
+		return "", suffix
+	case prefix > 0:
+		return current[prefix-1].GetID(), suffix
+	case len(current) > 0:
+		if after := preceding(current[0]); after != "" {
+			return after, suffix
+		}
+		return current[0].GetID(), suffix - 1
+	}
+	// The children are child pages and databases, if any; the blocks
+	// follow them.
+	return "", suffix
+}
+
+func blockMarkdownEqual(a, b notionapi.Block) bool {
+	return a.GetType() == b.GetType() && blockToMarkdown(a, 1) == blockToMarkdown(b, 1)
+}
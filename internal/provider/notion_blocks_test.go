@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestInlineBlock(t *testing.T) {
+	blocks := markdownToBlocks("<columns>\n<column>\n\n<details>\n<summary>Escalation</summary>\n\n- page\n\n</details>\n\n</column>\n<column>\n\nRight\n\n</column>\n</columns>")
+	list := blocks[0].(*notionapi.ColumnListBlock)
+
+	sent := inlineBlock(list, notionNestingLimit).(*notionapi.ColumnListBlock)
+	if len(sent.ColumnList.Children) != 2 {
+		t.Fatalf("sent %d columns, want 2", len(sent.ColumnList.Children))
+	}
+	toggle := sent.ColumnList.Children[0].(*notionapi.ColumnBlock).Column.Children[0].(*notionapi.ToggleBlock)
+	if len(toggle.Toggle.Children) != 0 {
+		t.Errorf("sent the toggle with %d children, want none past the nesting limit", len(toggle.Toggle.Children))
+	}
+	original := list.ColumnList.Children[0].(*notionapi.ColumnBlock).Column.Children[0].(*notionapi.ToggleBlock)
+	if len(original.Toggle.Children) != 1 {
+		t.Errorf("inlineBlock() changed the children of the original toggle")
+	}
+
+	grid := make([][]string, notionAppendLimit+50)
+	for i := range grid {
+		grid[i] = []string{"cell"}
+	}
+	table := &notionapi.TableBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeTableBlock),
+		Table:      notionapi.Table{TableWidth: 1, Children: tableRowBlocks(grid)},
+	}
+	if got := len(blockChildren(inlineBlock(table, notionNestingLimit))); got != notionAppendLimit {
+		t.Errorf("sent the table with %d rows, want %d", got, notionAppendLimit)
+	}
+	if got := blockChildren(inlineBlock(toggle, notionNestingLimit)); got != nil {
+		t.Errorf("sent a toggle with children %v", got)
+	}
+}
+
+func TestSyncAnchor(t *testing.T) {
+	block := func(id string) notionapi.Block {
+		return &notionapi.ParagraphBlock{BasicBlock: notionapi.BasicBlock{ID: notionapi.BlockID(id), Type: notionapi.BlockTypeParagraph}}
+	}
+	a, b, c := block("a"), block("b"), block("c")
+	page := &notionapi.ChildPageBlock{BasicBlock: notionapi.BasicBlock{ID: "page", Type: notionapi.BlockTypeChildPage}}
+
+	for _, tc := range []struct {
+		name           string
+		children       []notionapi.Block
+		prefix, suffix int
+		inserting      bool
+		after          notionapi.BlockID
+		wantSuffix     int
+	}{
+		{"replace after a child page", []notionapi.Block{a, page, b, c}, 1, 1, true, "page", 1},
+		{"replace the first block", []notionapi.Block{a, b, page}, 0, 1, true, "a", 1},
+		{"insert in between", []notionapi.Block{a, page, b}, 1, 1, true, "a", 1},
+		{"insert after a leading child page", []notionapi.Block{page, a, b}, 0, 2, true, "page", 2},
+		{"insert at the start", []notionapi.Block{a, b, page}, 0, 2, true, "a", 1},
+		{"nothing to do", []notionapi.Block{a, b}, 2, 0, false, "", 0},
+		{"no blocks yet", []notionapi.Block{page}, 0, 0, true, "", 0},
+	} {
+		var current []notionapi.Block
+		for _, b := range tc.children {
+			if !isChildObjectBlock(b) {
+				current = append(current, b)
+			}
+		}
+		after, suffix := syncAnchor(tc.children, current, tc.prefix, tc.suffix, tc.inserting)
+		if after != tc.after || suffix != tc.wantSuffix {
+			t.Errorf("%s: syncAnchor() = %q, %d, want %q, %d", tc.name, after, suffix, tc.after, tc.wantSuffix)
+		}
+	}
+}
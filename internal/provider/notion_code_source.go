@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionCodeHashKey is the private state key of the checksum of the code of a
+// block sourced from a file, as Notion returned it when it was written. Drift
+// is detected against it rather than against the file, so that the way
+// Notion stores the code doesn't show as a change.
+const notionCodeHashKey = "code_sha256"
+
+// notionRichTextArrayLimit is the maximum number of rich text objects of the
+// text of a block.
+const notionRichTextArrayLimit = 100
+
+// codeContent returns the code of a code block model: the content of its
+// source file if it has one.
+func codeContent(m *notionCodeBlockModel) string {
+	if !m.SourceFile.IsNull() {
+		return m.Source
+	}
+	return m.Code.ValueString()
+}
+
+// loadCodeSource reads the source file of a code block model into Source and
+// returns the checksum of its content.
+func loadCodeSource(m *notionCodeBlockModel) (string, error) {
+	name := m.SourceFile.ValueString()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("%s is not UTF-8 text", name)
+	}
+	if n := len(splitRichText(string(data), nil)); n > notionRichTextArrayLimit {
+		return "", fmt.Errorf("%s is too long, code blocks hold at most %d characters", name, notionRichTextArrayLimit*notionRichTextLimit)
+	}
+	m.Source = string(data)
+	return contentSHA256(m.Source), nil
+}
+
+// contentSHA256 returns the hex encoded SHA-256 checksum of s.
+func contentSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// codeSourceHash returns the private state value that records the code of a
+// block sourced from a file, or nil if the block is not sourced from a file.
+func codeSourceHash(m *notionBlockResourceModel, b notionapi.Block) []byte {
+	code, ok := b.(*notionapi.CodeBlock)
+	if !ok || m.Code == nil || m.Code.SourceFile.IsNull() {
+		return nil
+	}
+	v, _ := json.Marshal(contentSHA256(richTextPlainText(code.Code.RichText)))
+	return v
+}
+
+// flattenCodeSource reports drift of the code of a block sourced from a file.
+// If the code no longer matches the checksum recorded when it was written,
+// source_sha256 is set to the checksum of the code in Notion, so that the
+// next plan writes the file again.
+func flattenCodeSource(m *notionBlockResourceModel, b notionapi.Block, recorded []byte) {
+	code, ok := b.(*notionapi.CodeBlock)
+	if !ok || m.Code == nil || m.Code.SourceFile.IsNull() || len(recorded) == 0 {
+		return
+	}
+	var want string
+	if err := json.Unmarshal(recorded, &want); err != nil {
+		return
+	}
+	if got := contentSHA256(richTextPlainText(code.Code.RichText)); got != want {
+		m.Code.SourceSHA256 = types.StringValue(got)
+	}
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionBlockResourceCodeSource(t *testing.T) {
+	parentID := testAccParentPageID(t)
+	source := filepath.Join(t.TempDir(), "deploy.sh")
+	if err := os.WriteFile(source, []byte("#!/bin/sh\nterraform apply\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := testAccNotionBlockResourceConfig(parentID, fmt.Sprintf(`code = { source_file = %q, language = "shell" }`, source))
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: config,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "code.source_sha256", contentSHA256("#!/bin/sh\nterraform apply\n")),
+					tfresource.TestCheckNoResourceAttr("yoloexp_notion_block.test", "code.code"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(source, []byte(strings.Repeat("echo ok\n", 500)), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check:  tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "code.source_sha256", contentSHA256(strings.Repeat("echo ok\n", 500))),
+			},
+		},
+	})
+}
+
+func TestCodeSource(t *testing.T) {
+	content := strings.Repeat("x", notionRichTextLimit) + "\ny"
+	source := filepath.Join(t.TempDir(), "main.tf")
+	if err := os.WriteFile(source, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := notionBlockResourceModel{
+		Code: &notionCodeBlockModel{
+			SourceFile: types.StringValue(source),
+			Language:   types.StringValue("plain text"),
+		},
+	}
+	sum, err := loadCodeSource(m.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != contentSHA256(content) {
+		t.Errorf("loadCodeSource() = %s, want %s", sum, contentSHA256(content))
+	}
+	m.Code.SourceSHA256 = types.StringValue(sum)
+
+	b := expandBlock(&m).(*notionapi.CodeBlock)
+	if len(b.Code.RichText) != 2 || richTextPlainText(b.Code.RichText) != content {
+		t.Errorf("code is split into %d rich text objects, want 2", len(b.Code.RichText))
+	}
+
+	recorded := codeSourceHash(&m, b)
+	flattenCodeSource(&m, b, recorded)
+	if m.Code.SourceSHA256.ValueString() != sum {
+		t.Errorf("flattenCodeSource() reported drift of unchanged code")
+	}
+	b.Code.RichText = plainRichText("edited in Notion")
+	flattenCodeSource(&m, b, recorded)
+	if m.Code.SourceSHA256.ValueString() != contentSHA256("edited in Notion") {
+		t.Errorf("flattenCodeSource() didn't report drift of edited code")
+	}
+
+	if err := os.WriteFile(source, []byte(strings.Repeat("x", notionRichTextArrayLimit*notionRichTextLimit+1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCodeSource(m.Code); err == nil {
+		t.Error("loadCodeSource() accepted a file longer than a code block holds")
+	}
+}
@@ -16,16 +16,42 @@ var (
 )
 
 type notionDatabaseModel struct {
-	ID          types.String                  `tfsdk:"id"`
-	URL         types.String                  `tfsdk:"url"`
-	ParentID    types.String                  `tfsdk:"parent_id"`
-	CreatedTime types.String                  `tfsdk:"created_time"`
-	Properties  []notionDatabasePropertyModel `tfsdk:"properties"`
+	ID          types.String                           `tfsdk:"id"`
+	URL         types.String                           `tfsdk:"url"`
+	ParentID    types.String                           `tfsdk:"parent_id"`
+	ParentType  types.String                           `tfsdk:"parent_type"`
+	CreatedTime types.String                           `tfsdk:"created_time"`
+	Properties  map[string]notionDatabasePropertyModel `tfsdk:"properties"`
 }
 
 type notionDatabasePropertyModel struct {
-	Name types.String `tfsdk:"name"`
-	Type types.String `tfsdk:"type"`
+	ID       types.String               `tfsdk:"id"`
+	Type     types.String               `tfsdk:"type"`
+	Number   *notionNumberConfigModel   `tfsdk:"number"`
+	Options  []notionSelectOptionModel  `tfsdk:"options"`
+	Status   *notionStatusConfigModel   `tfsdk:"status"`
+	Relation *notionRelationConfigModel `tfsdk:"relation"`
+	Rollup   *notionRollupConfigModel   `tfsdk:"rollup"`
+	Formula  *notionFormulaConfigModel  `tfsdk:"formula"`
+}
+
+type notionStatusConfigModel struct {
+	Options []notionStatusOptionModel `tfsdk:"options"`
+	Groups  []notionStatusGroupModel  `tfsdk:"groups"`
+}
+
+type notionStatusOptionModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Color types.String `tfsdk:"color"`
+	Group types.String `tfsdk:"group"`
+}
+
+type notionStatusGroupModel struct {
+	ID      types.String   `tfsdk:"id"`
+	Name    types.String   `tfsdk:"name"`
+	Color   types.String   `tfsdk:"color"`
+	Options []types.String `tfsdk:"options"`
 }
 
 func NewNotionDatabaseDataSource() datasource.DataSource {
@@ -33,7 +59,7 @@ func NewNotionDatabaseDataSource() datasource.DataSource {
 }
 
 type notionDatabaseDataSource struct {
-	client *notionapi.Client
+	client *notionClient
 }
 
 func (d *notionDatabaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,26 +80,165 @@ func (d *notionDatabaseDataSource) Schema(ctx context.Context, req datasource.Sc
 				Computed:            true,
 			},
 			"parent_id": schema.StringAttribute{
-				MarkdownDescription: "The database's parent id.",
+				MarkdownDescription: "The database's parent id. Empty for workspace parents.",
+				Computed:            true,
+			},
+			"parent_type": schema.StringAttribute{
+				MarkdownDescription: "The database's parent type, one of `page_id`, `database_id`, `block_id` or `workspace`.",
 				Computed:            true,
 			},
 			"created_time": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when this database was created.",
 				Computed:            true,
 			},
-			"properties": schema.ListNestedAttribute{
-				MarkdownDescription: "The properties of the database.",
+			"properties": schema.MapNestedAttribute{
+				MarkdownDescription: "The properties of the database, keyed by name.",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							MarkdownDescription: "The property's name.",
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The property's id.",
 							Computed:            true,
 						},
 						"type": schema.StringAttribute{
 							MarkdownDescription: "The property's type.",
 							Computed:            true,
 						},
+						"number": schema.SingleNestedAttribute{
+							MarkdownDescription: "Settings of a `number` property.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"format": schema.StringAttribute{
+									MarkdownDescription: "How the number is displayed.",
+									Computed:            true,
+								},
+							},
+						},
+						"options": schema.ListNestedAttribute{
+							MarkdownDescription: "The options of a `select` or `multi_select` property.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "The option's id.",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "The option's name.",
+										Computed:            true,
+									},
+									"color": schema.StringAttribute{
+										MarkdownDescription: "The option's color.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"relation": schema.SingleNestedAttribute{
+							MarkdownDescription: "Settings of a `relation` property.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"database_id": schema.StringAttribute{
+									MarkdownDescription: "The id of the related database.",
+									Computed:            true,
+								},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Either `single_property` or `dual_property`.",
+									Computed:            true,
+								},
+								"synced_property_name": schema.StringAttribute{
+									MarkdownDescription: "The name of the synced property in the related database of a `dual_property` relation.",
+									Computed:            true,
+								},
+								"synced_property_id": schema.StringAttribute{
+									MarkdownDescription: "The id of the synced property in the related database of a `dual_property` relation.",
+									Computed:            true,
+								},
+							},
+						},
+						"rollup": schema.SingleNestedAttribute{
+							MarkdownDescription: "Settings of a `rollup` property.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"relation_property": schema.StringAttribute{
+									MarkdownDescription: "The name of the relation property that is rolled up.",
+									Computed:            true,
+								},
+								"rollup_property": schema.StringAttribute{
+									MarkdownDescription: "The name of the property of the related database that is rolled up.",
+									Computed:            true,
+								},
+								"function": schema.StringAttribute{
+									MarkdownDescription: "The function that aggregates the rolled up values.",
+									Computed:            true,
+								},
+							},
+						},
+						"formula": schema.SingleNestedAttribute{
+							MarkdownDescription: "Settings of a `formula` property.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"expression": schema.StringAttribute{
+									MarkdownDescription: "The formula expression, referencing properties by name.",
+									Computed:            true,
+								},
+							},
+						},
+						"status": schema.SingleNestedAttribute{
+							MarkdownDescription: "Settings of a `status` property.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"options": schema.ListNestedAttribute{
+									MarkdownDescription: "The statuses a row can have.",
+									Computed:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"id": schema.StringAttribute{
+												MarkdownDescription: "The status's id.",
+												Computed:            true,
+											},
+											"name": schema.StringAttribute{
+												MarkdownDescription: "The status's name.",
+												Computed:            true,
+											},
+											"color": schema.StringAttribute{
+												MarkdownDescription: "The status's color.",
+												Computed:            true,
+											},
+											"group": schema.StringAttribute{
+												MarkdownDescription: "The name of the group the status belongs to.",
+												Computed:            true,
+											},
+										},
+									},
+								},
+								"groups": schema.ListNestedAttribute{
+									MarkdownDescription: "The groups of statuses, such as `To-do`, `In progress` and `Complete`.",
+									Computed:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"id": schema.StringAttribute{
+												MarkdownDescription: "The group's id.",
+												Computed:            true,
+											},
+											"name": schema.StringAttribute{
+												MarkdownDescription: "The group's name.",
+												Computed:            true,
+											},
+											"color": schema.StringAttribute{
+												MarkdownDescription: "The group's color.",
+												Computed:            true,
+											},
+											"options": schema.ListAttribute{
+												MarkdownDescription: "The names of the statuses in the group.",
+												ElementType:         types.StringType,
+												Computed:            true,
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -88,7 +253,7 @@ func (d *notionDatabaseDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	db, err := d.client.Database.Get(ctx, notionapi.DatabaseID(config.ID.ValueString()))
+	db, err := getDatabase(ctx, d.client, notionapi.DatabaseID(config.ID.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to get database",
@@ -97,17 +262,17 @@ func (d *notionDatabaseDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	parentType, parentID := flattenParent(db.Parent)
 	state := &notionDatabaseModel{
 		ID:          types.StringValue(db.ID.String()),
 		URL:         types.StringValue(db.URL),
-		ParentID:    types.StringValue(db.Parent.PageID.String()),
+		ParentID:    types.StringValue(parentID),
+		ParentType:  types.StringValue(parentType),
 		CreatedTime: types.StringValue(db.CreatedTime.String()),
 	}
+	state.Properties = make(map[string]notionDatabasePropertyModel, len(db.Properties))
 	for k, v := range db.Properties {
-		state.Properties = append(state.Properties, notionDatabasePropertyModel{
-			Name: types.StringValue(k),
-			Type: types.StringValue(string(v.GetType())),
-		})
+		state.Properties[k] = flattenDatabaseProperty(db.PropertyIDs[k], v)
 	}
 
 	diags := resp.State.Set(ctx, state)
@@ -117,16 +282,87 @@ func (d *notionDatabaseDataSource) Read(ctx context.Context, req datasource.Read
 	}
 }
 
+// flattenDatabaseProperty converts a property schema returned by the Notion
+// API into a property model, with the settings of its kind.
+func flattenDatabaseProperty(id string, c notionapi.PropertyConfig) notionDatabasePropertyModel {
+	m := notionDatabasePropertyModel{
+		ID:   types.StringValue(id),
+		Type: types.StringValue(string(c.GetType())),
+	}
+
+	switch v := c.(type) {
+	case *notionapi.NumberPropertyConfig:
+		m.Number = &notionNumberConfigModel{Format: types.StringValue(string(v.Number.Format))}
+	case *notionapi.SelectPropertyConfig:
+		m.Options = flattenSelectOptions(v.Select.Options, nil)
+	case *notionapi.MultiSelectPropertyConfig:
+		m.Options = flattenSelectOptions(v.MultiSelect.Options, nil)
+	case *notionapi.StatusPropertyConfig:
+		m.Status = flattenStatusConfig(v.Status)
+	case *notionRelationPropertyConfig:
+		m.Relation = flattenRelationConfig(v, nil)
+	case *notionapi.RollupPropertyConfig:
+		m.Rollup = flattenRollupConfig(v)
+	case *notionapi.FormulaPropertyConfig:
+		m.Formula = &notionFormulaConfigModel{Expression: types.StringValue(v.Formula.Expression)}
+	}
+
+	return m
+}
+
+// flattenStatusConfig converts the options and groups of a status property
+// into a status model.
+func flattenStatusConfig(c notionapi.StatusConfig) *notionStatusConfigModel {
+	names := make(map[string]string, len(c.Options))
+	for _, o := range c.Options {
+		names[o.ID.String()] = o.Name
+	}
+
+	m := &notionStatusConfigModel{
+		Options: make([]notionStatusOptionModel, 0, len(c.Options)),
+		Groups:  make([]notionStatusGroupModel, 0, len(c.Groups)),
+	}
+	groups := make(map[string]string, len(c.Options))
+	for _, g := range c.Groups {
+		group := notionStatusGroupModel{
+			ID:      types.StringValue(g.ID.String()),
+			Name:    types.StringValue(g.Name),
+			Color:   types.StringValue(g.Color),
+			Options: make([]types.String, 0, len(g.OptionIDs)),
+		}
+		for _, id := range g.OptionIDs {
+			groups[id.String()] = g.Name
+			if name, ok := names[id.String()]; ok {
+				group.Options = append(group.Options, types.StringValue(name))
+			}
+		}
+		m.Groups = append(m.Groups, group)
+	}
+	for _, o := range c.Options {
+		option := notionStatusOptionModel{
+			ID:    types.StringValue(o.ID.String()),
+			Name:  types.StringValue(o.Name),
+			Color: types.StringValue(o.Color.String()),
+			Group: types.StringNull(),
+		}
+		if g, ok := groups[o.ID.String()]; ok {
+			option.Group = types.StringValue(g)
+		}
+		m.Options = append(m.Options, option)
+	}
+	return m
+}
+
 func (d *notionDatabaseDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*notionapi.Client)
+	client, ok := req.ProviderData.(*notionClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
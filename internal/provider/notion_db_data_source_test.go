@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestFlattenStatusConfig(t *testing.T) {
+	m := flattenStatusConfig(notionapi.StatusConfig{
+		Options: []notionapi.Option{
+			{ID: "o1", Name: "Not started", Color: "default"},
+			{ID: "o2", Name: "In progress", Color: "blue"},
+			{ID: "o3", Name: "Done", Color: "green"},
+		},
+		Groups: []notionapi.GroupConfig{
+			{ID: "g1", Name: "To-do", Color: "gray", OptionIDs: []notionapi.ObjectID{"o1"}},
+			{ID: "g2", Name: "In progress", Color: "blue", OptionIDs: []notionapi.ObjectID{"o2"}},
+			{ID: "g3", Name: "Complete", Color: "green", OptionIDs: []notionapi.ObjectID{"o3"}},
+		},
+	})
+
+	if len(m.Options) != 3 || len(m.Groups) != 3 {
+		t.Fatalf("flattenStatusConfig() = %d options and %d groups, want 3 and 3", len(m.Options), len(m.Groups))
+	}
+	if got := m.Options[2].Group.ValueString(); got != "Complete" {
+		t.Errorf("group of Done = %q, want Complete", got)
+	}
+	if got := m.Groups[0].Options; len(got) != 1 || got[0].ValueString() != "Not started" {
+		t.Errorf("options of To-do = %v, want [Not started]", got)
+	}
+}
+
+func TestFlattenDatabaseProperty(t *testing.T) {
+	number := flattenDatabaseProperty("n1", &notionapi.NumberPropertyConfig{
+		Type:   notionapi.PropertyConfigTypeNumber,
+		Number: notionapi.NumberFormat{Format: notionapi.FormatNumber},
+	})
+	if number.Number == nil || number.Number.Format.ValueString() != "number" {
+		t.Errorf("number settings = %+v, want format number", number.Number)
+	}
+
+	rollup := flattenDatabaseProperty("r1", &notionapi.RollupPropertyConfig{
+		Type: notionapi.PropertyConfigTypeRollup,
+		Rollup: notionapi.RollupConfig{
+			RelationPropertyName: "Tasks",
+			RollupPropertyName:   "Estimate",
+			Function:             notionapi.FunctionSum,
+		},
+	})
+	if rollup.Rollup == nil || rollup.Rollup.Function.ValueString() != "sum" || rollup.Number != nil {
+		t.Errorf("rollup settings = %+v, want function sum and no other settings", rollup)
+	}
+}
@@ -0,0 +1,895 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionDatabasePropertyTypes lists the property types that can be managed
+// through the database resource.
+var notionDatabasePropertyTypes = []string{
+	string(notionapi.PropertyConfigTypeTitle),
+	string(notionapi.PropertyConfigTypeRichText),
+	string(notionapi.PropertyConfigTypeNumber),
+	string(notionapi.PropertyConfigTypeSelect),
+	string(notionapi.PropertyConfigTypeMultiSelect),
+	string(notionapi.PropertyConfigTypeDate),
+	string(notionapi.PropertyConfigTypePeople),
+	string(notionapi.PropertyConfigTypeFiles),
+	string(notionapi.PropertyConfigTypeCheckbox),
+	string(notionapi.PropertyConfigTypeURL),
+	string(notionapi.PropertyConfigTypeEmail),
+	string(notionapi.PropertyConfigTypePhoneNumber),
+	string(notionapi.PropertyConfigTypeRelation),
+	string(notionapi.PropertyConfigTypeRollup),
+	string(notionapi.PropertyConfigTypeFormula),
+	string(notionapi.PropertyConfigCreatedTime),
+	string(notionapi.PropertyConfigCreatedBy),
+	string(notionapi.PropertyConfigLastEditedTime),
+	string(notionapi.PropertyConfigLastEditedBy),
+}
+
+// isManagedPropertyType reports whether properties of type t can be managed
+// through the database resource. Other properties, such as status columns,
+// are left alone.
+func isManagedPropertyType(t notionapi.PropertyConfigType) bool {
+	return slices.Contains(notionDatabasePropertyTypes, string(t))
+}
+
+// notionNumberFormats lists the number formats supported by Notion.
+var notionNumberFormats = []string{
+	"number", "number_with_commas", "percent", "dollar", "canadian_dollar",
+	"euro", "pound", "yen", "ruble", "rupee", "won", "yuan", "real", "lira",
+	"rupiah", "franc", "hong_kong_dollar", "new_zealand_dollar", "krona",
+	"norwegian_krone", "mexican_peso", "rand", "new_taiwan_dollar",
+	"danish_krone", "zloty", "baht", "forint", "koruna", "shekel",
+	"chilean_peso", "philippine_peso", "dirham", "colombian_peso", "riyal",
+	"ringgit", "leu", "argentine_peso", "uruguayan_peso", "singapore_dollar",
+}
+
+// notionRelationTypes lists the kinds of relation properties.
+var notionRelationTypes = []string{
+	string(notionapi.RelationSingleProperty),
+	string(notionapi.RelationDualProperty),
+}
+
+// notionRollupFunctions lists the functions that aggregate rollup values.
+var notionRollupFunctions = []string{
+	"count", "count_values", "empty", "not_empty", "unique", "show_unique",
+	"percent_empty", "percent_not_empty", "sum", "average", "median", "min",
+	"max", "range", "earliest_date", "latest_date", "date_range", "checked",
+	"unchecked", "percent_checked", "percent_unchecked", "count_per_group",
+	"percent_per_group", "show_original",
+}
+
+// notionOptionColors lists the colors of select and multi-select options.
+var notionOptionColors = []string{
+	"default", "gray", "brown", "orange", "yellow", "green", "blue", "purple", "pink", "red",
+}
+
+// notionDatabaseResourcePropertyModel is a database property in the database
+// resource. Properties are keyed by name in the schema; Name is set from the
+// key by propertyList.
+type notionDatabaseResourcePropertyModel struct {
+	ID           types.String               `tfsdk:"id"`
+	Name         types.String               `tfsdk:"-"`
+	PreviousName types.String               `tfsdk:"previous_name"`
+	Type         types.String               `tfsdk:"type"`
+	Number       *notionNumberConfigModel   `tfsdk:"number"`
+	Options      []notionSelectOptionModel  `tfsdk:"options"`
+	Relation     *notionRelationConfigModel `tfsdk:"relation"`
+	Rollup       *notionRollupConfigModel   `tfsdk:"rollup"`
+	Formula      *notionFormulaConfigModel  `tfsdk:"formula"`
+}
+
+type notionNumberConfigModel struct {
+	Format types.String `tfsdk:"format"`
+}
+
+type notionSelectOptionModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Color types.String `tfsdk:"color"`
+}
+
+type notionRelationConfigModel struct {
+	DatabaseID         types.String `tfsdk:"database_id"`
+	Type               types.String `tfsdk:"type"`
+	SyncedPropertyName types.String `tfsdk:"synced_property_name"`
+	SyncedPropertyID   types.String `tfsdk:"synced_property_id"`
+}
+
+type notionRollupConfigModel struct {
+	RelationProperty types.String `tfsdk:"relation_property"`
+	RollupProperty   types.String `tfsdk:"rollup_property"`
+	Function         types.String `tfsdk:"function"`
+}
+
+type notionFormulaConfigModel struct {
+	Expression types.String `tfsdk:"expression"`
+}
+
+// notionRelationPropertyConfig is the schema of a relation property. Unlike
+// notionapi.RelationPropertyConfig it follows the shape of the current API
+// version, where the synced property of a dual relation is nested in
+// dual_property.
+type notionRelationPropertyConfig struct {
+	ID       notionapi.PropertyID         `json:"id,omitempty"`
+	Type     notionapi.PropertyConfigType `json:"type"`
+	Relation notionRelationConfig         `json:"relation"`
+}
+
+func (p notionRelationPropertyConfig) GetType() notionapi.PropertyConfigType {
+	return p.Type
+}
+
+type notionRelationConfig struct {
+	DatabaseID     notionapi.DatabaseID         `json:"database_id"`
+	Type           notionapi.RelationConfigType `json:"type,omitempty"`
+	SingleProperty *struct{}                    `json:"single_property,omitempty"`
+	DualProperty   *notionDualProperty          `json:"dual_property,omitempty"`
+}
+
+type notionDualProperty struct {
+	SyncedPropertyName string               `json:"synced_property_name,omitempty"`
+	SyncedPropertyID   notionapi.PropertyID `json:"synced_property_id,omitempty"`
+}
+
+// notionRollupPropertyConfig is the schema of a rollup property in a request.
+// Unlike notionapi.RollupPropertyConfig it leaves out the property ids, which
+// the API rejects when empty.
+type notionRollupPropertyConfig struct {
+	Type   notionapi.PropertyConfigType `json:"type"`
+	Rollup notionRollupConfig           `json:"rollup"`
+}
+
+func (p notionRollupPropertyConfig) GetType() notionapi.PropertyConfigType {
+	return p.Type
+}
+
+type notionRollupConfig struct {
+	RelationPropertyName string `json:"relation_property_name"`
+	RollupPropertyName   string `json:"rollup_property_name"`
+	Function             string `json:"function"`
+}
+
+// databasePropertyResourceAttributes returns the schema of a database property
+// in the database resource, apart from its name.
+func databasePropertyResourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The property's id, which stays the same when the property is renamed.",
+			Computed:            true,
+		},
+		"previous_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the existing property this property renames, keeping its values. Without it, a property with a new key is added and the property with the old key is deleted. Not needed for the title property.",
+			Optional:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "The property's type.",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(notionDatabasePropertyTypes...),
+			},
+		},
+		"number": schema.SingleNestedAttribute{
+			MarkdownDescription: "Settings of a `number` property.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"format": schema.StringAttribute{
+					MarkdownDescription: "How the number is displayed. Defaults to `number`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(string(notionapi.FormatNumber)),
+					Validators: []validator.String{
+						stringvalidator.OneOf(notionNumberFormats...),
+					},
+				},
+			},
+		},
+		"options": schema.ListNestedAttribute{
+			MarkdownDescription: "The options of a `select` or `multi_select` property. When unset, options are not managed and Notion adds them as rows use new values.",
+			Optional:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "The option's id. Kept stable across changes so rows keep their value.",
+						Computed:            true,
+					},
+					"name": schema.StringAttribute{
+						MarkdownDescription: "The option's name.",
+						Required:            true,
+					},
+					"color": schema.StringAttribute{
+						MarkdownDescription: "The option's color. Notion picks one when unset.",
+						Optional:            true,
+						Computed:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(notionOptionColors...),
+						},
+					},
+				},
+			},
+		},
+		"relation": schema.SingleNestedAttribute{
+			MarkdownDescription: "Settings of a `relation` property.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"database_id": schema.StringAttribute{
+					MarkdownDescription: "The id of the related database.",
+					Required:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Whether the relation is one-way (`single_property`) or also adds a synced property to the related database (`dual_property`). Defaults to `single_property`. The synced property of a `dual_property` relation is left out of the related database's `properties` unless declared there.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(string(notionapi.RelationSingleProperty)),
+					Validators: []validator.String{
+						stringvalidator.OneOf(notionRelationTypes...),
+					},
+				},
+				"synced_property_name": schema.StringAttribute{
+					MarkdownDescription: "The name of the synced property in the related database of a `dual_property` relation.",
+					Computed:            true,
+				},
+				"synced_property_id": schema.StringAttribute{
+					MarkdownDescription: "The id of the synced property in the related database of a `dual_property` relation.",
+					Computed:            true,
+				},
+			},
+		},
+		"rollup": schema.SingleNestedAttribute{
+			MarkdownDescription: "Settings of a `rollup` property.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"relation_property": schema.StringAttribute{
+					MarkdownDescription: "The name of the relation property of this database to roll up.",
+					Required:            true,
+				},
+				"rollup_property": schema.StringAttribute{
+					MarkdownDescription: "The name of the property of the related database to roll up.",
+					Required:            true,
+				},
+				"function": schema.StringAttribute{
+					MarkdownDescription: "The function that aggregates the rolled up values.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(notionRollupFunctions...),
+					},
+				},
+			},
+		},
+		"formula": schema.SingleNestedAttribute{
+			MarkdownDescription: "Settings of a `formula` property.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "The formula expression. Properties are referenced by name, as in `prop(\"Due\")`.",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+// expandPropertyConfig converts a property model into the schema object the
+// Notion API expects.
+func expandPropertyConfig(p notionDatabaseResourcePropertyModel) (notionapi.PropertyConfig, error) {
+	t := notionapi.PropertyConfigType(p.Type.ValueString())
+	switch t {
+	case notionapi.PropertyConfigTypeTitle:
+		return notionapi.TitlePropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeRichText:
+		return notionapi.RichTextPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeNumber:
+		format := notionapi.FormatNumber
+		if p.Number != nil && !p.Number.Format.IsNull() {
+			format = notionapi.FormatType(p.Number.Format.ValueString())
+		}
+		return notionapi.NumberPropertyConfig{Type: t, Number: notionapi.NumberFormat{Format: format}}, nil
+	case notionapi.PropertyConfigTypeSelect:
+		return notionapi.SelectPropertyConfig{Type: t, Select: notionapi.Select{Options: expandSelectOptions(p.Options)}}, nil
+	case notionapi.PropertyConfigTypeMultiSelect:
+		return notionapi.MultiSelectPropertyConfig{Type: t, MultiSelect: notionapi.Select{Options: expandSelectOptions(p.Options)}}, nil
+	case notionapi.PropertyConfigTypeDate:
+		return notionapi.DatePropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypePeople:
+		return notionapi.PeoplePropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeFiles:
+		return notionapi.FilesPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeCheckbox:
+		return notionapi.CheckboxPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeURL:
+		return notionapi.URLPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeEmail:
+		return notionapi.EmailPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypePhoneNumber:
+		return notionapi.PhoneNumberPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigTypeRelation:
+		if p.Relation == nil {
+			return nil, fmt.Errorf("relation settings are required")
+		}
+		return expandRelationConfig(p.Relation), nil
+	case notionapi.PropertyConfigTypeRollup:
+		if p.Rollup == nil {
+			return nil, fmt.Errorf("rollup settings are required")
+		}
+		return notionRollupPropertyConfig{Type: t, Rollup: notionRollupConfig{
+			RelationPropertyName: p.Rollup.RelationProperty.ValueString(),
+			RollupPropertyName:   p.Rollup.RollupProperty.ValueString(),
+			Function:             p.Rollup.Function.ValueString(),
+		}}, nil
+	case notionapi.PropertyConfigTypeFormula:
+		if p.Formula == nil {
+			return nil, fmt.Errorf("formula settings are required")
+		}
+		return notionapi.FormulaPropertyConfig{Type: t, Formula: notionapi.FormulaConfig{Expression: p.Formula.Expression.ValueString()}}, nil
+	case notionapi.PropertyConfigCreatedTime:
+		return notionapi.CreatedTimePropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigCreatedBy:
+		return notionapi.CreatedByPropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigLastEditedTime:
+		return notionapi.LastEditedTimePropertyConfig{Type: t}, nil
+	case notionapi.PropertyConfigLastEditedBy:
+		return notionapi.LastEditedByPropertyConfig{Type: t}, nil
+	}
+	return nil, fmt.Errorf("unsupported property type %q", t)
+}
+
+// expandPropertyConfigs converts all property models into the property schema
+// of a database create request.
+func expandPropertyConfigs(props []notionDatabaseResourcePropertyModel) (notionapi.PropertyConfigs, error) {
+	configs := make(notionapi.PropertyConfigs, len(props))
+	for _, p := range props {
+		c, err := expandPropertyConfig(p)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", p.Name.ValueString(), err)
+		}
+		configs[p.Name.ValueString()] = c
+	}
+	return configs, nil
+}
+
+// flattenPropertyConfig converts a property schema returned by the Notion API
+// into a property model. The prior model, if any, is used to keep optional
+// settings that the API reports with their default values unset.
+func flattenPropertyConfig(id, name string, c notionapi.PropertyConfig, prior *notionDatabaseResourcePropertyModel) notionDatabaseResourcePropertyModel {
+	m := notionDatabaseResourcePropertyModel{
+		ID:           types.StringValue(id),
+		Name:         types.StringValue(name),
+		PreviousName: types.StringNull(),
+		Type:         types.StringValue(string(c.GetType())),
+	}
+	if prior != nil {
+		m.PreviousName = prior.PreviousName
+	}
+
+	switch v := c.(type) {
+	case *notionapi.NumberPropertyConfig:
+		format := string(v.Number.Format)
+		if format != string(notionapi.FormatNumber) || (prior != nil && prior.Number != nil) {
+			m.Number = &notionNumberConfigModel{Format: types.StringValue(format)}
+		}
+	case *notionapi.SelectPropertyConfig:
+		if prior == nil || prior.Options != nil {
+			m.Options = flattenSelectOptions(v.Select.Options, prior)
+		}
+	case *notionapi.MultiSelectPropertyConfig:
+		if prior == nil || prior.Options != nil {
+			m.Options = flattenSelectOptions(v.MultiSelect.Options, prior)
+		}
+	case *notionRelationPropertyConfig:
+		m.Relation = flattenRelationConfig(v, prior)
+	case *notionapi.RollupPropertyConfig:
+		m.Rollup = flattenRollupConfig(v)
+	case *notionapi.FormulaPropertyConfig:
+		m.Formula = &notionFormulaConfigModel{Expression: types.StringValue(v.Formula.Expression)}
+		if prior != nil && prior.Formula != nil && formulaExpressionEqual(prior.Formula.Expression.ValueString(), v.Formula.Expression) {
+			// Notion reformats expressions; keep the configured form.
+			m.Formula.Expression = prior.Formula.Expression
+		}
+	}
+
+	return m
+}
+
+// expandRelationConfig converts relation settings into a relation property
+// schema.
+func expandRelationConfig(m *notionRelationConfigModel) notionRelationPropertyConfig {
+	databaseID := m.DatabaseID.ValueString()
+	if id, err := parseNotionID(databaseID); err == nil {
+		databaseID = id
+	}
+
+	c := notionRelationPropertyConfig{
+		Type: notionapi.PropertyConfigTypeRelation,
+		Relation: notionRelationConfig{
+			DatabaseID: notionapi.DatabaseID(databaseID),
+			Type:       notionapi.RelationConfigType(m.Type.ValueString()),
+		},
+	}
+	if c.Relation.Type == notionapi.RelationDualProperty {
+		c.Relation.DualProperty = &notionDualProperty{}
+	} else {
+		c.Relation.Type = notionapi.RelationSingleProperty
+		c.Relation.SingleProperty = &struct{}{}
+	}
+	return c
+}
+
+// flattenRelationConfig converts a relation property schema into relation
+// settings. The database id keeps the format of prior when both refer to the
+// same database.
+func flattenRelationConfig(c *notionRelationPropertyConfig, prior *notionDatabaseResourcePropertyModel) *notionRelationConfigModel {
+	m := &notionRelationConfigModel{
+		DatabaseID:         types.StringValue(c.Relation.DatabaseID.String()),
+		Type:               types.StringValue(string(c.Relation.Type)),
+		SyncedPropertyName: types.StringNull(),
+		SyncedPropertyID:   types.StringNull(),
+	}
+	if prior != nil && prior.Relation != nil && notionIDEqual(prior.Relation.DatabaseID.ValueString(), c.Relation.DatabaseID.String()) {
+		m.DatabaseID = prior.Relation.DatabaseID
+	}
+	if d := c.Relation.DualProperty; d != nil {
+		m.SyncedPropertyName = types.StringValue(d.SyncedPropertyName)
+		m.SyncedPropertyID = types.StringValue(d.SyncedPropertyID.String())
+	}
+	return m
+}
+
+// flattenRollupConfig converts a rollup property schema into rollup settings.
+func flattenRollupConfig(c *notionapi.RollupPropertyConfig) *notionRollupConfigModel {
+	return &notionRollupConfigModel{
+		RelationProperty: types.StringValue(c.Rollup.RelationPropertyName),
+		RollupProperty:   types.StringValue(c.Rollup.RollupPropertyName),
+		Function:         types.StringValue(string(c.Rollup.Function)),
+	}
+}
+
+// isDualRelation reports whether c is the schema of a two-way relation.
+func isDualRelation(c notionapi.PropertyConfig) bool {
+	rc, ok := c.(*notionRelationPropertyConfig)
+	return ok && rc.Relation.DualProperty != nil
+}
+
+// expandSelectOptions converts option models into Notion options. Options
+// that already exist keep their id so rows referencing them keep their value.
+func expandSelectOptions(options []notionSelectOptionModel) []notionapi.Option {
+	opts := make([]notionapi.Option, 0, len(options))
+	for _, o := range options {
+		opt := notionapi.Option{Name: o.Name.ValueString()}
+		if !o.ID.IsNull() && !o.ID.IsUnknown() {
+			opt.ID = notionapi.PropertyID(o.ID.ValueString())
+		}
+		if !o.Color.IsNull() && !o.Color.IsUnknown() {
+			opt.Color = notionapi.Color(o.Color.ValueString())
+		}
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+// flattenSelectOptions converts Notion options into option models, keeping
+// the order of the options in prior.
+func flattenSelectOptions(options []notionapi.Option, prior *notionDatabaseResourcePropertyModel) []notionSelectOptionModel {
+	byName := make(map[string]notionapi.Option, len(options))
+	for _, o := range options {
+		byName[o.Name] = o
+	}
+
+	flatten := func(o notionapi.Option) notionSelectOptionModel {
+		return notionSelectOptionModel{
+			ID:    types.StringValue(o.ID.String()),
+			Name:  types.StringValue(o.Name),
+			Color: types.StringValue(o.Color.String()),
+		}
+	}
+
+	models := make([]notionSelectOptionModel, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	if prior != nil {
+		for _, p := range prior.Options {
+			if o, ok := byName[p.Name.ValueString()]; ok && !seen[o.Name] {
+				models = append(models, flatten(o))
+				seen[o.Name] = true
+			}
+		}
+	}
+	for _, o := range options {
+		if !seen[o.Name] {
+			models = append(models, flatten(o))
+			seen[o.Name] = true
+		}
+	}
+	return models
+}
+
+// setPropertyComputed fills the computed attributes of planned properties
+// from the database Notion returned.
+func setPropertyComputed(props []notionDatabaseResourcePropertyModel, db *notionDatabase) {
+	configs := db.Properties
+	for i := range props {
+		p := &props[i]
+		p.ID = types.StringValue(db.PropertyIDs[p.Name.ValueString()])
+		var options []notionapi.Option
+		switch v := configs[p.Name.ValueString()].(type) {
+		case *notionapi.SelectPropertyConfig:
+			options = v.Select.Options
+		case *notionapi.MultiSelectPropertyConfig:
+			options = v.MultiSelect.Options
+		}
+		byName := make(map[string]notionapi.Option, len(options))
+		for _, o := range options {
+			byName[o.Name] = o
+		}
+		if rc, ok := configs[p.Name.ValueString()].(*notionRelationPropertyConfig); ok && p.Relation != nil {
+			got := flattenRelationConfig(rc, nil)
+			p.Relation.SyncedPropertyName = got.SyncedPropertyName
+			p.Relation.SyncedPropertyID = got.SyncedPropertyID
+		}
+		for j := range p.Options {
+			o := &p.Options[j]
+			got, ok := byName[o.Name.ValueString()]
+			if o.ID.IsUnknown() {
+				o.ID = types.StringNull()
+				if ok {
+					o.ID = types.StringValue(got.ID.String())
+				}
+			}
+			if o.Color.IsUnknown() {
+				o.Color = types.StringNull()
+				if ok {
+					o.Color = types.StringValue(got.Color.String())
+				}
+			}
+		}
+	}
+}
+
+// planPropertyComputed copies the computed attributes of properties that
+// exist in state into the plan, so unchanged values don't show up as known
+// only after apply.
+func planPropertyComputed(plan, state []notionDatabaseResourcePropertyModel) {
+	for i, j := range matchProperties(plan, state) {
+		if j < 0 {
+			continue
+		}
+		p, old := &plan[i], state[j]
+		p.ID = old.ID
+		if !old.Type.Equal(p.Type) {
+			continue
+		}
+
+		if p.Relation != nil && old.Relation != nil &&
+			notionIDEqual(p.Relation.DatabaseID.ValueString(), old.Relation.DatabaseID.ValueString()) &&
+			p.Relation.Type.Equal(old.Relation.Type) {
+			p.Relation.SyncedPropertyName = old.Relation.SyncedPropertyName
+			p.Relation.SyncedPropertyID = old.Relation.SyncedPropertyID
+		}
+
+		oldOptions := make(map[string]notionSelectOptionModel, len(old.Options))
+		for _, o := range old.Options {
+			oldOptions[o.Name.ValueString()] = o
+		}
+		for j := range p.Options {
+			o := &p.Options[j]
+			prev, ok := oldOptions[o.Name.ValueString()]
+			if !ok {
+				continue
+			}
+			if o.ID.IsUnknown() {
+				o.ID = prev.ID
+			}
+			if o.Color.IsUnknown() {
+				o.Color = prev.Color
+			}
+		}
+	}
+}
+
+// removedSelectOptions returns, per property name in state, the options of
+// select and multi-select properties in state that are no longer in the plan.
+func removedSelectOptions(plan, state []notionDatabaseResourcePropertyModel) map[string][]string {
+	removed := map[string][]string{}
+	for i, j := range matchProperties(plan, state) {
+		if j < 0 {
+			continue
+		}
+		p, old := plan[i], state[j]
+		if !old.Type.Equal(p.Type) || p.Options == nil {
+			continue
+		}
+		keep := make(map[string]bool, len(p.Options))
+		for _, o := range p.Options {
+			keep[o.Name.ValueString()] = true
+		}
+		for _, o := range old.Options {
+			if !keep[o.Name.ValueString()] {
+				removed[old.Name.ValueString()] = append(removed[old.Name.ValueString()], o.Name.ValueString())
+			}
+		}
+	}
+	return removed
+}
+
+// propertyList returns the properties of a property map with their names set,
+// title property first and the others by name.
+func propertyList(m map[string]notionDatabaseResourcePropertyModel) []notionDatabaseResourcePropertyModel {
+	props := make([]notionDatabaseResourcePropertyModel, 0, len(m))
+	for name, p := range m {
+		p.Name = types.StringValue(name)
+		props = append(props, p)
+	}
+	sort.Slice(props, func(i, j int) bool {
+		ti := props[i].Type.ValueString() == string(notionapi.PropertyConfigTypeTitle)
+		tj := props[j].Type.ValueString() == string(notionapi.PropertyConfigTypeTitle)
+		if ti != tj {
+			return ti
+		}
+		return props[i].Name.ValueString() < props[j].Name.ValueString()
+	})
+	return props
+}
+
+// propertyMap returns a property map keyed by the names of props.
+func propertyMap(props []notionDatabaseResourcePropertyModel) map[string]notionDatabaseResourcePropertyModel {
+	m := make(map[string]notionDatabaseResourcePropertyModel, len(props))
+	for _, p := range props {
+		m[p.Name.ValueString()] = p
+	}
+	return m
+}
+
+// flattenPropertyConfigs converts the property schema of a database into
+// property models. Properties keep the order they have in prior, following
+// renames by property id; properties unknown to prior are appended in name
+// order, title property first.
+//
+// Properties of types that can't be configured, see isManagedPropertyType,
+// are left out so they are never deleted.
+//
+// Two-way relations declared by another database add a synced property to
+// this one. Unless this database was just imported (prior is empty), such
+// properties are left out when they aren't in prior so the database owning
+// the relation can manage it without perpetual diffs here.
+func flattenPropertyConfigs(db *notionDatabase, prior []notionDatabaseResourcePropertyModel) []notionDatabaseResourcePropertyModel {
+	configs := db.Properties
+	namesByID := make(map[string]string, len(db.PropertyIDs))
+	for name, id := range db.PropertyIDs {
+		namesByID[id] = name
+	}
+
+	var props []notionDatabaseResourcePropertyModel
+	seen := make(map[string]bool, len(configs))
+	for i := range prior {
+		name := prior[i].Name.ValueString()
+		if _, ok := configs[name]; !ok {
+			// The property may have been renamed outside of Terraform.
+			name = namesByID[prior[i].ID.ValueString()]
+		}
+		c, ok := configs[name]
+		if !ok || seen[name] || !isManagedPropertyType(c.GetType()) {
+			continue
+		}
+		props = append(props, flattenPropertyConfig(db.PropertyIDs[name], name, c, &prior[i]))
+		seen[name] = true
+	}
+
+	var rest []string
+	for name, c := range configs {
+		if seen[name] || !isManagedPropertyType(c.GetType()) || (len(prior) > 0 && isDualRelation(c)) {
+			continue
+		}
+		rest = append(rest, name)
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		ti := configs[rest[i]].GetType() == notionapi.PropertyConfigTypeTitle
+		tj := configs[rest[j]].GetType() == notionapi.PropertyConfigTypeTitle
+		if ti != tj {
+			return ti
+		}
+		return rest[i] < rest[j]
+	})
+	for _, name := range rest {
+		props = append(props, flattenPropertyConfig(db.PropertyIDs[name], name, configs[name], nil))
+	}
+
+	return props
+}
+
+// matchProperties returns, for each planned property, the index of the
+// property in state it updates, or -1 for a new property. A planned property
+// matches, in order:
+//
+//  1. the property of the same name that was renamed from its previous_name
+//     already, so the rename isn't applied twice;
+//  2. the property named by its previous_name;
+//  3. the property of the same name;
+//  4. for the title property, the title property in state, as a database
+//     has exactly one.
+//
+// Properties matched by another name are renamed instead of being re-created,
+// which keeps their values. Other properties are only renamed when
+// previous_name says so, so that removing one property and adding another of
+// the same type doesn't carry the old values over.
+func matchProperties(plan, state []notionDatabaseResourcePropertyModel) []int {
+	match := make([]int, len(plan))
+	used := make([]bool, len(state))
+	for i := range match {
+		match[i] = -1
+	}
+
+	find := func(name string) int {
+		for j, old := range state {
+			if !used[j] && old.Name.ValueString() == name {
+				return j
+			}
+		}
+		return -1
+	}
+	pass := func(pick func(p notionDatabaseResourcePropertyModel) int) {
+		for i, p := range plan {
+			if match[i] >= 0 {
+				continue
+			}
+			if j := pick(p); j >= 0 {
+				match[i] = j
+				used[j] = true
+			}
+		}
+	}
+
+	pass(func(p notionDatabaseResourcePropertyModel) int {
+		if p.PreviousName.ValueString() == "" {
+			return -1
+		}
+		j := find(p.Name.ValueString())
+		if j >= 0 && state[j].PreviousName.Equal(p.PreviousName) {
+			return j
+		}
+		return -1
+	})
+	pass(func(p notionDatabaseResourcePropertyModel) int {
+		if p.PreviousName.ValueString() == "" {
+			return -1
+		}
+		return find(p.PreviousName.ValueString())
+	})
+	pass(func(p notionDatabaseResourcePropertyModel) int {
+		return find(p.Name.ValueString())
+	})
+
+	pass(func(p notionDatabaseResourcePropertyModel) int {
+		if p.Type.ValueString() != string(notionapi.PropertyConfigTypeTitle) {
+			return -1
+		}
+		for j, old := range state {
+			if !used[j] && old.Type.Equal(p.Type) {
+				return j
+			}
+		}
+		return -1
+	})
+
+	return match
+}
+
+// namedPropertyConfig renames an existing property while updating its schema.
+type namedPropertyConfig struct {
+	Name   string
+	Config notionapi.PropertyConfig
+}
+
+func (c namedPropertyConfig) GetType() notionapi.PropertyConfigType {
+	return c.Config.GetType()
+}
+
+func (c namedPropertyConfig) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.Config)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	raw["name"] = c.Name
+	return json.Marshal(raw)
+}
+
+// diffPropertyConfigs returns the property changes that turn the database
+// schema described by state into the one described by plan, as the updates
+// to send in order. Removed properties map to a nil config, which the Notion
+// API treats as a delete. Properties matched to a state property of another
+// name, see matchProperties, are renamed. A new property taking the name of
+// a property that is renamed or deleted is added by a second update, as in
+// the same request Notion can't tell it from the old property.
+func diffPropertyConfigs(state, plan []notionDatabaseResourcePropertyModel) ([]notionapi.PropertyConfigs, error) {
+	configs := notionapi.PropertyConfigs{}
+	added := notionapi.PropertyConfigs{}
+	freed := make(map[string]bool)
+
+	match := matchProperties(plan, state)
+	matched := make([]bool, len(state))
+	for i, p := range plan {
+		name := p.Name.ValueString()
+		want, err := expandPropertyConfig(p)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+
+		j := match[i]
+		if j < 0 {
+			added[name] = want
+			continue
+		}
+		matched[j] = true
+
+		old := state[j]
+		if old.Name.ValueString() != name {
+			configs[propertyKey(old)] = namedPropertyConfig{Name: name, Config: want}
+			freed[old.Name.ValueString()] = true
+			continue
+		}
+		have, err := expandPropertyConfig(old)
+		if err == nil && propertyConfigEqual(have, want) {
+			continue
+		}
+		configs[name] = want
+	}
+
+	for j, old := range state {
+		// Never delete a property that couldn't be declared in its place.
+		if !matched[j] && isManagedPropertyType(notionapi.PropertyConfigType(old.Type.ValueString())) {
+			configs[propertyKey(old)] = nil
+			freed[old.Name.ValueString()] = true
+		}
+	}
+
+	later := notionapi.PropertyConfigs{}
+	for name, c := range added {
+		if freed[name] {
+			later[name] = c
+		} else {
+			configs[name] = c
+		}
+	}
+	updates := []notionapi.PropertyConfigs{configs}
+	if len(later) > 0 {
+		updates = append(updates, later)
+	}
+	return updates, nil
+}
+
+// propertyKey returns the key that identifies an existing property in an
+// update request: its id, which unlike its name can't be taken by another
+// property of the same request.
+func propertyKey(p notionDatabaseResourcePropertyModel) string {
+	if id := p.ID.ValueString(); id != "" {
+		return id
+	}
+	return p.Name.ValueString()
+}
+
+// propertyConfigEqual reports whether two property schemas serialize to the
+// same request payload.
+func propertyConfigEqual(a, b notionapi.PropertyConfig) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ab) == string(bb)
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDiffPropertyConfigs(t *testing.T) {
+	prop := func(name, typ string) notionDatabaseResourcePropertyModel {
+		return notionDatabaseResourcePropertyModel{Name: types.StringValue(name), Type: types.StringValue(typ)}
+	}
+
+	state := []notionDatabaseResourcePropertyModel{
+		prop("Name", "title"),
+		prop("Notes", "rich_text"),
+		prop("Done", "checkbox"),
+	}
+	plan := []notionDatabaseResourcePropertyModel{
+		prop("Task", "title"),
+		prop("Notes", "rich_text"),
+		prop("Due", "date"),
+	}
+
+	configs, err := diffPropertyConfigs(state, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"Done":null,"Due":{"type":"date","date":{}},"Name":{"name":"Task","title":{},"type":"title"}}]`
+	if string(got) != want {
+		t.Errorf("diffPropertyConfigs() = %s, want %s", got, want)
+	}
+}
+
+func TestPlanPropertyComputedKeepsOptionIDs(t *testing.T) {
+	option := func(id, name, color string) notionSelectOptionModel {
+		o := notionSelectOptionModel{ID: types.StringUnknown(), Name: types.StringValue(name), Color: types.StringUnknown()}
+		if id != "" {
+			o.ID = types.StringValue(id)
+		}
+		if color != "" {
+			o.Color = types.StringValue(color)
+		}
+		return o
+	}
+
+	state := []notionDatabaseResourcePropertyModel{{
+		Name:    types.StringValue("Stage"),
+		Type:    types.StringValue("select"),
+		Options: []notionSelectOptionModel{option("a1", "Todo", "red"), option("b2", "Done", "green"), option("c3", "Dropped", "gray")},
+	}}
+	plan := []notionDatabaseResourcePropertyModel{{
+		Name:    types.StringValue("Stage"),
+		Type:    types.StringValue("select"),
+		Options: []notionSelectOptionModel{option("", "Todo", "blue"), option("", "Done", ""), option("", "Doing", "")},
+	}}
+
+	planPropertyComputed(plan, state)
+
+	got, err := json.Marshal(expandSelectOptions(plan[0].Options))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"id":"a1","name":"Todo","color":"blue"},{"id":"b2","name":"Done","color":"green"},{"name":"Doing"}]`
+	if string(got) != want {
+		t.Errorf("expandSelectOptions() = %s, want %s", got, want)
+	}
+
+	removed := removedSelectOptions(plan, state)
+	if len(removed["Stage"]) != 1 || removed["Stage"][0] != "Dropped" {
+		t.Errorf("removedSelectOptions() = %v, want [Dropped] for Stage", removed)
+	}
+}
+
+func TestFlattenPropertyConfigsDualRelation(t *testing.T) {
+	var db notionDatabase
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"Project": {"id": "p1", "type": "relation", "relation": {
+				"database_id": "3a5f8f9c-0a5a-4a8a-9d3b-6c6f1f0e2b7a",
+				"type": "dual_property",
+				"dual_property": {"synced_property_name": "Tasks", "synced_property_id": "s1"}
+			}},
+			"Blocked by": {"id": "b1", "type": "relation", "relation": {
+				"database_id": "0c3e1f2a-6b1d-4c8e-9f0a-2d4b6a8c0e1f",
+				"type": "dual_property",
+				"dual_property": {"synced_property_name": "Blocks", "synced_property_id": "s2"}
+			}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prior := []notionDatabaseResourcePropertyModel{
+		{Name: types.StringValue("Name"), Type: types.StringValue("title")},
+		{
+			Name: types.StringValue("Project"),
+			Type: types.StringValue("relation"),
+			Relation: &notionRelationConfigModel{
+				DatabaseID: types.StringValue("3a5f8f9c0a5a4a8a9d3b6c6f1f0e2b7a"),
+				Type:       types.StringValue("dual_property"),
+			},
+		},
+	}
+
+	got := flattenPropertyConfigs(&db, prior)
+	if len(got) != 2 {
+		t.Fatalf("flattenPropertyConfigs() returned %d properties, want 2 (unmanaged synced relation left out)", len(got))
+	}
+	r := got[1].Relation
+	if r == nil {
+		t.Fatal("flattenPropertyConfigs() lost the relation settings of Project")
+	}
+	if r.DatabaseID.ValueString() != "3a5f8f9c0a5a4a8a9d3b6c6f1f0e2b7a" {
+		t.Errorf("database_id = %s, want the configured format", r.DatabaseID)
+	}
+	if r.SyncedPropertyName.ValueString() != "Tasks" || r.SyncedPropertyID.ValueString() != "s1" {
+		t.Errorf("synced property = %s/%s, want Tasks/s1", r.SyncedPropertyName, r.SyncedPropertyID)
+	}
+
+	// On import the state has no properties yet.
+	var imported map[string]notionDatabaseResourcePropertyModel
+	if got := flattenPropertyConfigs(&db, propertyList(imported)); len(got) != 3 {
+		t.Errorf("flattenPropertyConfigs() on import returned %d properties, want 3", len(got))
+	}
+}
+
+func TestFlattenPropertyConfigsImportUnmanaged(t *testing.T) {
+	var db notionDatabase
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"Notes": {"id": "n1", "type": "rich_text", "rich_text": {}},
+			"Status": {"id": "s1", "type": "status", "status": {"options": [], "groups": []}},
+			"ID": {"id": "u1", "type": "unique_id", "unique_id": {"prefix": "TASK"}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported := flattenPropertyConfigs(&db, nil)
+	for _, p := range imported {
+		if name := p.Name.ValueString(); name == "Status" || name == "ID" {
+			t.Errorf("flattenPropertyConfigs() imported %s property %q", p.Type.ValueString(), name)
+		}
+	}
+
+	// The configuration matches the imported properties.
+	updates, err := diffPropertyConfigs(imported, imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(updates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{}]`; string(got) != want {
+		t.Errorf("diffPropertyConfigs() after import = %s, want %s", got, want)
+	}
+}
+
+func TestDiffPropertyConfigsRenames(t *testing.T) {
+	prop := func(id, name, previous, typ string) notionDatabaseResourcePropertyModel {
+		p := notionDatabaseResourcePropertyModel{
+			ID:           types.StringValue(id),
+			Name:         types.StringValue(name),
+			PreviousName: types.StringNull(),
+			Type:         types.StringValue(typ),
+		}
+		if id == "" {
+			p.ID = types.StringUnknown()
+		}
+		if previous != "" {
+			p.PreviousName = types.StringValue(previous)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name  string
+		state []notionDatabaseResourcePropertyModel
+		plan  []notionDatabaseResourcePropertyModel
+		want  string
+	}{
+		{
+			name:  "replaced without hint",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "Notes", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "Summary", "", "rich_text")},
+			want:  `[{"Summary":{"type":"rich_text","rich_text":{}},"a1":null}]`,
+		},
+		{
+			name:  "renamed title",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "Notes", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Task", "", "title"), prop("", "Notes", "", "rich_text")},
+			want:  `[{"title":{"name":"Task","title":{},"type":"title"}}]`,
+		},
+		{
+			name:  "ambiguous without hint",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "A", "", "rich_text"), prop("b2", "B", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "C", "", "rich_text"), prop("", "D", "", "rich_text")},
+			want:  `[{"C":{"type":"rich_text","rich_text":{}},"D":{"type":"rich_text","rich_text":{}},"a1":null,"b2":null}]`,
+		},
+		{
+			name:  "previous name",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "A", "", "rich_text"), prop("b2", "B", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "C", "B", "rich_text"), prop("", "A", "", "rich_text")},
+			want:  `[{"b2":{"name":"C","rich_text":{},"type":"rich_text"}}]`,
+		},
+		{
+			name:  "rename and reuse the old name",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "A", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "B", "A", "rich_text"), prop("", "A", "", "rich_text")},
+			want:  `[{"a1":{"name":"B","rich_text":{},"type":"rich_text"}},{"A":{"type":"rich_text","rich_text":{}}}]`,
+		},
+		{
+			name:  "rename and reuse the old name for another type",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "A", "", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "B", "A", "rich_text"), prop("", "A", "", "checkbox")},
+			want:  `[{"a1":{"name":"B","rich_text":{},"type":"rich_text"}},{"A":{"type":"checkbox","checkbox":{}}}]`,
+		},
+		{
+			name:  "unmanaged property kept",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("s1", "Status", "", "status")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title")},
+			want:  `[{}]`,
+		},
+		{
+			name:  "swap already applied",
+			state: []notionDatabaseResourcePropertyModel{prop("title", "Name", "", "title"), prop("a1", "B", "A", "rich_text"), prop("b2", "A", "B", "rich_text")},
+			plan:  []notionDatabaseResourcePropertyModel{prop("", "Name", "", "title"), prop("", "A", "B", "rich_text"), prop("", "B", "A", "rich_text")},
+			want:  `[{}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs, err := diffPropertyConfigs(tt.state, tt.plan)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(configs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("diffPropertyConfigs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
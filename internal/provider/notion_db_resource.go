@@ -4,8 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jomei/notionapi"
@@ -13,16 +21,38 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource = &notionDatabaseResource{}
+	_ resource.Resource                   = &notionDatabaseResource{}
+	_ resource.ResourceWithConfigure      = &notionDatabaseResource{}
+	_ resource.ResourceWithValidateConfig = &notionDatabaseResource{}
+	_ resource.ResourceWithModifyPlan     = &notionDatabaseResource{}
+	_ resource.ResourceWithImportState    = &notionDatabaseResource{}
+	_ resource.ResourceWithUpgradeState   = &notionDatabaseResource{}
 )
 
 type notionDatabaseResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	URL         types.String `tfsdk:"url"`
-	ParentID    types.String `tfsdk:"parent_id"`
-	CreatedTime types.String `tfsdk:"created_time"`
+	ID                  types.String                                   `tfsdk:"id"`
+	URL                 types.String                                   `tfsdk:"url"`
+	ParentID            types.String                                   `tfsdk:"parent_id"`
+	ParentType          types.String                                   `tfsdk:"parent_type"`
+	CurrentParentID     types.String                                   `tfsdk:"current_parent_id"`
+	CurrentParentType   types.String                                   `tfsdk:"current_parent_type"`
+	CreatedTime         types.String                                   `tfsdk:"created_time"`
+	Title               types.String                                   `tfsdk:"title"`
+	TitleRichText       []notionRichTextModel                          `tfsdk:"title_rich_text"`
+	Description         types.String                                   `tfsdk:"description"`
+	DescriptionRichText []notionRichTextModel                          `tfsdk:"description_rich_text"`
+	IsInline            types.Bool                                     `tfsdk:"is_inline"`
+	Icon                *notionIconModel                               `tfsdk:"icon"`
+	Cover               *notionCoverModel                              `tfsdk:"cover"`
+	Properties          map[string]notionDatabaseResourcePropertyModel `tfsdk:"properties"`
+	OnDestroy           types.String                                   `tfsdk:"on_destroy"`
 }
 
+const (
+	onDestroyArchive = "archive"
+	onDestroyAbandon = "abandon"
+)
+
 // NewNotionDatabaseResource is a helper function to simplify the provider implementation.
 func NewNotionDatabaseResource() resource.Resource {
 	return &notionDatabaseResource{}
@@ -30,7 +60,7 @@ func NewNotionDatabaseResource() resource.Resource {
 
 // notionDatabaseResource is the resource implementation.
 type notionDatabaseResource struct {
-	client *notionapi.Client
+	client *notionClient
 }
 
 // Metadata returns the resource type name.
@@ -42,44 +72,337 @@ func (r *notionDatabaseResource) Metadata(_ context.Context, req resource.Metada
 func (r *notionDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Notion database data source",
+		// Version 1 keys properties by name instead of listing them.
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Notion database id",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"url": schema.StringAttribute{
 				MarkdownDescription: "Notion database url",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"parent_id": schema.StringAttribute{
-				MarkdownDescription: "The database's parent id. Only supports page_id.",
+				MarkdownDescription: "The database's parent id.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_type": schema.StringAttribute{
+				MarkdownDescription: "The database's parent type. Databases are created under a page, so it can only be set to `page_id`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(notionapi.ParentTypePageID)),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_parent_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the database's parent in Notion. It differs from `parent_id` once the database is moved outside of Terraform, which doesn't replace the database.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_parent_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the database's parent in Notion, such as `block_id` once the database is moved into a block.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_time": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when this database was created.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The database's title as plain text. Exactly one of `title` and `title_rich_text` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("title_rich_text")),
+					plainTextValidator(),
+				},
+			},
+			"title_rich_text": richTextResourceAttribute("The database's title as styled rich text."),
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The database's description as plain text. Conflicts with `description_rich_text`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("description_rich_text")),
+					plainTextValidator(),
+				},
+			},
+			"description_rich_text": richTextResourceAttribute("The database's description as styled rich text."),
+			"is_inline": schema.BoolAttribute{
+				MarkdownDescription: "Whether the database is shown inline in its parent page instead of as a child page. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"icon":  iconResourceAttribute("The database's icon."),
+			"cover": coverResourceAttribute("The database's cover image."),
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the database when the resource is destroyed. `archive` (the default) moves it to the trash, `abandon` leaves it in the workspace and only removes it from Terraform state.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(onDestroyArchive),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyArchive, onDestroyAbandon),
+				},
+			},
+			"properties": schema.MapNestedAttribute{
+				MarkdownDescription: "The properties of the database, keyed by name. Exactly one property must be of type `title`. To rename a property, keeping its values, change its key and set `previous_name`; the title property is renamed by changing its key alone. Properties of types that can't be declared here, such as `status`, are left as they are.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: databasePropertyResourceAttributes(),
+				},
 			},
-			// "properties": schema.ListNestedAttribute{
-			// 	MarkdownDescription: "The properties of the database.",
-			// 	Computed:            true,
-			// 	NestedObject: schema.NestedAttributeObject{
-			// 		Attributes: map[string]schema.Attribute{
-			// 			"name": schema.StringAttribute{
-			// 				MarkdownDescription: "The property's name.",
-			// 				Computed:            true,
-			// 			},
-			// 			"type": schema.StringAttribute{
-			// 				MarkdownDescription: "The property's type.",
-			// 				Computed:            true,
-			// 			},
-			// 		},
-			// 	},
-			// },
 		},
 	}
 }
 
+// ValidateConfig validates the property schema of the database.
+func (r *notionDatabaseResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if unknownModelValues(req.Config.Raw, "title_rich_text", "description_rich_text", "icon", "cover", "properties") {
+		// Validated once the values are known.
+		return
+	}
+
+	var config notionDatabaseResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	props := propertyList(config.Properties)
+	titles := 0
+	names := make(map[string]string, len(props))
+	renamed := make(map[string]bool, len(props))
+	for _, p := range props {
+		if p.Type.IsUnknown() {
+			// Can't validate values that are not known yet.
+			return
+		}
+
+		name := p.Name.ValueString()
+		names[name] = p.Type.ValueString()
+
+		if previous := p.PreviousName.ValueString(); previous != "" {
+			if renamed[previous] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(name).AtName("previous_name"),
+					"Duplicate previous name",
+					fmt.Sprintf("More than one property renames %q.", previous),
+				)
+			}
+			renamed[previous] = true
+		}
+
+		if p.Type.ValueString() == string(notionapi.PropertyConfigTypeTitle) {
+			titles++
+		}
+		if p.Number != nil && p.Type.ValueString() != string(notionapi.PropertyConfigTypeNumber) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("properties").AtMapKey(name).AtName("number"),
+				"Unexpected number settings",
+				fmt.Sprintf("Property %q is of type %q; number settings only apply to number properties.", name, p.Type.ValueString()),
+			)
+		}
+		if p.Options != nil {
+			switch p.Type.ValueString() {
+			case string(notionapi.PropertyConfigTypeSelect), string(notionapi.PropertyConfigTypeMultiSelect):
+			default:
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(name).AtName("options"),
+					"Unexpected options",
+					fmt.Sprintf("Property %q is of type %q; options only apply to select and multi_select properties.", name, p.Type.ValueString()),
+				)
+			}
+		}
+		for _, settings := range []struct {
+			attr string
+			typ  notionapi.PropertyConfigType
+			set  bool
+		}{
+			{"relation", notionapi.PropertyConfigTypeRelation, p.Relation != nil},
+			{"rollup", notionapi.PropertyConfigTypeRollup, p.Rollup != nil},
+			{"formula", notionapi.PropertyConfigTypeFormula, p.Formula != nil},
+		} {
+			isType := p.Type.ValueString() == string(settings.typ)
+			if isType && !settings.set {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(name).AtName(settings.attr),
+					fmt.Sprintf("Missing %s settings", settings.attr),
+					fmt.Sprintf("Property %q is of type %q and requires %s settings.", name, p.Type.ValueString(), settings.attr),
+				)
+			}
+			if !isType && settings.set {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(name).AtName(settings.attr),
+					fmt.Sprintf("Unexpected %s settings", settings.attr),
+					fmt.Sprintf("Property %q is of type %q; %s settings only apply to %s properties.", name, p.Type.ValueString(), settings.attr, settings.typ),
+				)
+			}
+		}
+		options := make(map[string]bool, len(p.Options))
+		for j, o := range p.Options {
+			if o.Name.IsUnknown() {
+				continue
+			}
+			if options[o.Name.ValueString()] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(name).AtName("options").AtListIndex(j).AtName("name"),
+					"Duplicate option name",
+					fmt.Sprintf("Option %q of property %q is declared more than once.", o.Name.ValueString(), name),
+				)
+			}
+			options[o.Name.ValueString()] = true
+		}
+	}
+
+	if titles != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("properties"),
+			"Invalid title property",
+			fmt.Sprintf("A database must have exactly one property of type \"title\", got %d.", titles),
+		)
+	}
+
+	// Rollups and formulas may only reference properties of this database.
+	for _, p := range props {
+		if p.Rollup != nil && !p.Rollup.RelationProperty.IsUnknown() {
+			relation := p.Rollup.RelationProperty.ValueString()
+			if names[relation] != string(notionapi.PropertyConfigTypeRelation) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("properties").AtMapKey(p.Name.ValueString()).AtName("rollup").AtName("relation_property"),
+					"Invalid rollup relation",
+					fmt.Sprintf("Rollup %q uses %q, which is not a relation property of this database.", p.Name.ValueString(), relation),
+				)
+			}
+		}
+		if p.Formula != nil && !p.Formula.Expression.IsUnknown() {
+			for _, ref := range formulaPropertyReferences(p.Formula.Expression.ValueString()) {
+				if _, ok := names[ref]; !ok {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("properties").AtMapKey(p.Name.ValueString()).AtName("formula").AtName("expression"),
+						"Unknown formula property",
+						fmt.Sprintf("Formula %q references property %q, which is not declared in this database.", p.Name.ValueString(), ref),
+					)
+				}
+			}
+		}
+	}
+}
+
+// ModifyPlan derives the plain text title and description from their rich
+// text form when the latter is configured, keeps the ids of existing select
+// options, and warns about removed options that rows still use.
+func (r *notionDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to do on destroy.
+		return
+	}
+	if unknownModelValues(req.Plan.Raw, "title_rich_text", "description_rich_text", "icon", "cover", "properties") {
+		// The computed values stay unknown until the values they derive
+		// from are known.
+		return
+	}
+
+	var plan notionDatabaseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.TitleRichText != nil && richTextKnown(plan.TitleRichText) {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("title"), segmentsPlainText(plan.TitleRichText))
+		resp.Diagnostics.Append(diags...)
+	}
+	if plan.DescriptionRichText != nil && richTextKnown(plan.DescriptionRichText) {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("description"), segmentsPlainText(plan.DescriptionRichText))
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if req.State.Raw.IsNull() {
+		// Nothing more to do on create.
+		return
+	}
+
+	var state notionDatabaseResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	props := propertyList(plan.Properties)
+	planPropertyComputed(props, propertyList(state.Properties))
+	diags = resp.Plan.SetAttribute(ctx, path.Root("properties"), propertyMap(props))
+	resp.Diagnostics.Append(diags...)
+
+	r.warnRemovedOptionsInUse(ctx, &plan, &state, &resp.Diagnostics)
+}
+
+// warnRemovedOptionsInUse warns about select options that are removed by the
+// plan while rows of the database still use them.
+func (r *notionDatabaseResource) warnRemovedOptionsInUse(ctx context.Context, plan, state *notionDatabaseResourceModel, diags *diag.Diagnostics) {
+	if r.client == nil {
+		return
+	}
+
+	planProps, stateProps := propertyList(plan.Properties), propertyList(state.Properties)
+	removed := removedSelectOptions(planProps, stateProps)
+	for i, j := range matchProperties(planProps, stateProps) {
+		if j < 0 {
+			continue
+		}
+		p, old := planProps[i], stateProps[j]
+		for _, option := range removed[old.Name.ValueString()] {
+			filter := notionapi.PropertyFilter{Property: old.Name.ValueString()}
+			if p.Type.ValueString() == string(notionapi.PropertyConfigTypeSelect) {
+				filter.Select = &notionapi.SelectFilterCondition{Equals: option}
+			} else {
+				filter.MultiSelect = &notionapi.MultiSelectFilterCondition{Contains: option}
+			}
+
+			res, err := r.client.Database.Query(ctx, notionapi.DatabaseID(state.ID.ValueString()), &notionapi.DatabaseQueryRequest{
+				Filter:   filter,
+				PageSize: 1,
+			})
+			if err != nil {
+				tflog.Warn(ctx, "Failed to check whether a removed option is in use", map[string]any{"property": p.Name.ValueString(), "option": option, "error": err.Error()})
+				continue
+			}
+			if len(res.Results) > 0 {
+				diags.AddAttributeWarning(
+					path.Root("properties").AtMapKey(p.Name.ValueString()).AtName("options"),
+					"Removed option is still in use",
+					fmt.Sprintf("Option %q of property %q is removed, but rows of the database still use it. Those rows will lose the value.", option, p.Name.ValueString()),
+				)
+			}
+		}
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *notionDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan notionDatabaseResourceModel
@@ -89,26 +412,16 @@ func (r *notionDatabaseResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	dbReq := &notionapi.DatabaseCreateRequest{
-		Parent: notionapi.Parent{
-			Type:   notionapi.ParentTypePageID,
-			PageID: notionapi.PageID(plan.ParentID.ValueString()),
-		},
-		Title: []notionapi.RichText{
-			{
-				Type: notionapi.ObjectTypeText,
-				Text: &notionapi.Text{Content: "Title"},
-			},
-		},
-		Properties: notionapi.PropertyConfigs{
-			"create": notionapi.TitlePropertyConfig{
-				Type: notionapi.PropertyConfigTypeTitle,
-			},
-		},
-		IsInline: false,
+	dbReq, err := newDatabaseCreateRequest(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid database properties",
+			err.Error(),
+		)
+		return
 	}
 
-	db, err := r.client.Database.Create(ctx, dbReq)
+	db, err := createDatabase(ctx, r.client, dbReq)
 	if err != nil {
 		tflog.Debug(ctx, "Failed to create database")
 		resp.Diagnostics.AddError(
@@ -119,14 +432,18 @@ func (r *notionDatabaseResource) Create(ctx context.Context, req resource.Create
 	}
 
 	plan.ID = types.StringValue(db.ID.String())
+	parentType, parentID := flattenParent(db.Parent)
+	plan.ParentType = types.StringValue(parentType)
+	plan.CurrentParentID = types.StringValue(parentID)
+	plan.CurrentParentType = types.StringValue(parentType)
 	plan.CreatedTime = types.StringValue(db.CreatedTime.String())
 	plan.URL = types.StringValue(db.URL)
-	// for k, v := range db.Properties {
-	// 	plan.Properties = append(plan.Properties, notionDatabasePropertyModel{
-	// 		Name: types.StringValue(k),
-	// 		Type: types.StringValue(string(v.GetType())),
-	// 	})
-	// }
+	plan.Title = types.StringValue(richTextPlainText(db.Title))
+	plan.Description = types.StringValue(richTextPlainText(db.Description))
+	setIconCoverComputed(plan.Icon, plan.Cover, db.Icon, db.Cover)
+	props := propertyList(plan.Properties)
+	setPropertyComputed(props, db)
+	plan.Properties = propertyMap(props)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -144,8 +461,13 @@ func (r *notionDatabaseResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(state.ID.ValueString()))
+	db, err := getDatabase(ctx, r.client, notionapi.DatabaseID(state.ID.ValueString()))
 	if err != nil {
+		if isNotionNotFound(err) {
+			tflog.Warn(ctx, "Database not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		tflog.Debug(ctx, "Failed to read database")
 		resp.Diagnostics.AddError(
 			"Failed to read database",
@@ -153,17 +475,40 @@ func (r *notionDatabaseResource) Read(ctx context.Context, req resource.ReadRequ
 		)
 		return
 	}
+	if db.Archived {
+		tflog.Warn(ctx, "Database is archived, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	state.ID = types.StringValue(db.ID.String())
 	state.URL = types.StringValue(db.URL)
-	state.ParentID = types.StringValue(db.Parent.PageID.String())
+	// A database moved outside of Terraform keeps its configured parent, which
+	// would otherwise plan to replace it.
+	parentType, parentID := flattenParent(db.Parent)
+	if state.ParentID.IsNull() {
+		// Imported.
+		state.ParentID = types.StringValue(parentID)
+		state.ParentType = types.StringValue(parentType)
+	}
+	state.CurrentParentID = types.StringValue(parentID)
+	state.CurrentParentType = types.StringValue(parentType)
 	state.CreatedTime = types.StringValue(db.CreatedTime.String())
-	// for k, v := range db.Properties {
-	// 	state.Properties = append(state.Properties, notionDatabasePropertyModel{
-	// 		Name: types.StringValue(k),
-	// 		Type: types.StringValue(string(v.GetType())),
-	// 	})
-	// }
+	state.Title = types.StringValue(richTextPlainText(db.Title))
+	if state.TitleRichText != nil {
+		state.TitleRichText = flattenRichText(db.Title)
+	}
+	state.Description = types.StringValue(richTextPlainText(db.Description))
+	if state.DescriptionRichText != nil {
+		state.DescriptionRichText = flattenRichText(db.Description)
+	}
+	state.IsInline = types.BoolValue(db.IsInline)
+	state.Icon = flattenIcon(db.Icon)
+	state.Cover = flattenCover(db.Cover)
+	state.Properties = propertyMap(flattenPropertyConfigs(db, propertyList(state.Properties)))
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(onDestroyArchive)
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -174,56 +519,74 @@ func (r *notionDatabaseResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *notionDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan notionDatabaseResourceModel
+	var plan, state notionDatabaseResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	db, err := r.client.Database.Get(ctx, notionapi.DatabaseID(plan.ID.ValueString()))
+	propUpdates, err := diffPropertyConfigs(propertyList(state.Properties), propertyList(plan.Properties))
 	if err != nil {
-		// Assume it's not found. BAD!
-		// Only make sure the database exists.
-		dbReq := &notionapi.DatabaseCreateRequest{
-			Parent: notionapi.Parent{
-				Type:   notionapi.ParentTypePageID,
-				PageID: notionapi.PageID(plan.ParentID.ValueString()),
-			},
-			Title: []notionapi.RichText{
-				{
-					Type: notionapi.ObjectTypeText,
-					Text: &notionapi.Text{Content: "Title"},
-				},
-			},
-			Properties: notionapi.PropertyConfigs{
-				"create": notionapi.TitlePropertyConfig{
-					Type: notionapi.PropertyConfigTypeTitle,
-				},
-			},
-			IsInline: false,
-		}
+		resp.Diagnostics.AddError(
+			"Invalid database properties",
+			err.Error(),
+		)
+		return
+	}
 
-		db, err = r.client.Database.Create(ctx, dbReq)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Failed to create database",
-				fmt.Sprintf("Failed to create database: %s", err),
-			)
-			return
+	updateReq := &notionDatabaseRequest{
+		Properties: propUpdates[0],
+	}
+	if !plan.Title.Equal(state.Title) || !richTextEqual(plan.TitleRichText, state.TitleRichText) {
+		updateReq.Title = databaseTitle(&plan)
+	}
+	if !plan.Description.Equal(state.Description) || !richTextEqual(plan.DescriptionRichText, state.DescriptionRichText) {
+		description := databaseDescription(&plan)
+		updateReq.Description = &description
+	}
+
+	if !plan.IsInline.Equal(state.IsInline) {
+		updateReq.IsInline = plan.IsInline.ValueBoolPointer()
+	}
+	if !iconEqual(plan.Icon, state.Icon) {
+		updateReq.Icon = iconUpdate(plan.Icon)
+	}
+	if !coverEqual(plan.Cover, state.Cover) {
+		updateReq.Cover = coverUpdate(plan.Cover)
+	}
+
+	var db *notionDatabase
+	if len(updateReq.Properties) > 0 || updateReq.Title != nil || updateReq.Description != nil || updateReq.IsInline != nil || updateReq.Icon != nil || updateReq.Cover != nil {
+		db, err = updateDatabase(ctx, r.client, notionapi.DatabaseID(state.ID.ValueString()), updateReq)
+		for _, props := range propUpdates[1:] {
+			if err != nil {
+				break
+			}
+			db, err = updateDatabase(ctx, r.client, notionapi.DatabaseID(state.ID.ValueString()), &notionDatabaseRequest{Properties: props})
 		}
+	} else {
+		db, err = getDatabase(ctx, r.client, notionapi.DatabaseID(state.ID.ValueString()))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update database",
+			fmt.Sprintf("Failed to update database: %s", err),
+		)
+		return
 	}
 
 	plan.ID = types.StringValue(db.ID.String())
 	plan.URL = types.StringValue(db.URL)
-	plan.ParentID = types.StringValue(db.Parent.PageID.String())
 	plan.CreatedTime = types.StringValue(db.CreatedTime.String())
-	// for k, v := range db.Properties {
-	// 	plan.Properties = append(plan.Properties, notionDatabasePropertyModel{
-	// 		Name: types.StringValue(k),
-	// 		Type: types.StringValue(string(v.GetType())),
-	// 	})
-	// }
+	plan.Title = types.StringValue(richTextPlainText(db.Title))
+	plan.Description = types.StringValue(richTextPlainText(db.Description))
+	setIconCoverComputed(plan.Icon, plan.Cover, db.Icon, db.Cover)
+	planProps := propertyList(plan.Properties)
+	setPropertyComputed(planProps, db)
+	plan.Properties = propertyMap(planProps)
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -234,7 +597,58 @@ func (r *notionDatabaseResource) Update(ctx context.Context, req resource.Update
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *notionDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Not supported.
+	var state notionDatabaseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.ValueString() == onDestroyAbandon {
+		tflog.Info(ctx, "Abandoning database", map[string]any{"id": state.ID.ValueString()})
+		return
+	}
+
+	db, err := getDatabase(ctx, r.client, notionapi.DatabaseID(state.ID.ValueString()))
+	if err != nil {
+		if isNotionNotFound(err) {
+			// Already gone.
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to delete database",
+			fmt.Sprintf("Failed to get database: %s", err),
+		)
+		return
+	}
+	if db.Archived {
+		// Already in the trash.
+		return
+	}
+
+	// Databases are blocks of their parent page, deleting the block moves the
+	// database to the trash.
+	if _, err := r.client.Block.Delete(ctx, notionapi.BlockID(state.ID.ValueString())); err != nil && !isNotionNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete database",
+			fmt.Sprintf("Failed to archive database: %s", err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing database by its id or URL.
+func (r *notionDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseNotionID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected a database id or URL: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 func (d *notionDatabaseResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -242,11 +656,11 @@ func (d *notionDatabaseResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*notionapi.Client)
+	client, ok := req.ProviderData.(*notionClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -254,3 +668,47 @@ func (d *notionDatabaseResource) Configure(_ context.Context, req resource.Confi
 
 	d.client = client
 }
+
+// newDatabaseCreateRequest builds the request that creates the database
+// described by plan.
+func newDatabaseCreateRequest(plan *notionDatabaseResourceModel) (*notionDatabaseRequest, error) {
+	props, err := expandPropertyConfigs(propertyList(plan.Properties))
+	if err != nil {
+		return nil, err
+	}
+
+	parent := expandParent(string(notionapi.ParentTypePageID), plan.ParentID.ValueString())
+	description := databaseDescription(plan)
+	dbReq := &notionDatabaseRequest{
+		Parent:      &parent,
+		Title:       databaseTitle(plan),
+		Description: &description,
+		Properties:  props,
+		IsInline:    plan.IsInline.ValueBoolPointer(),
+	}
+	if plan.Icon != nil {
+		dbReq.Icon = expandIcon(plan.Icon)
+	}
+	if plan.Cover != nil {
+		dbReq.Cover = expandCover(plan.Cover)
+	}
+	return dbReq, nil
+}
+
+// databaseTitle returns the title of the database described by plan,
+// preferring its rich text form.
+func databaseTitle(plan *notionDatabaseResourceModel) []notionapi.RichText {
+	if plan.TitleRichText != nil {
+		return expandRichText(plan.TitleRichText)
+	}
+	return plainRichText(plan.Title.ValueString())
+}
+
+// databaseDescription returns the description of the database described by
+// plan, preferring its rich text form.
+func databaseDescription(plan *notionDatabaseResourceModel) []notionapi.RichText {
+	if plan.DescriptionRichText != nil {
+		return expandRichText(plan.DescriptionRichText)
+	}
+	return plainRichText(plan.Description.ValueString())
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionDatabaseResource(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionDatabaseArchived,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNotionDatabaseResourceConfig(parentID, "Name", "dollar"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("yoloexp_notion_database.test", "id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "title", "Acceptance test Name"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "description", "Created by the yoloexp acceptance tests."),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "icon.emoji", "🧪"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "current_parent_type", "page_id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "properties.%", "3"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "properties.Name.type", "title"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "properties.Budget.number.format", "dollar"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "yoloexp_notion_database.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNotionDatabaseResourceConfig(parentID, "Task", "euro"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "title", "Acceptance test Task"),
+					resource.TestCheckNoResourceAttr("yoloexp_notion_database.test", "properties.Name.type"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "properties.Task.id", "title"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.test", "properties.Budget.number.format", "euro"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNotionDatabaseResourceConfig(parentID, titleName, format string) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_database" "test" {
+  parent_id   = %[1]q
+  title       = "Acceptance test %[2]s"
+  description = "Created by the yoloexp acceptance tests."
+  icon        = { emoji = "🧪" }
+
+  properties = {
+    %[2]q  = { type = "title" }
+    Budget = { type = "number", number = { format = %[3]q } }
+    Done   = { type = "checkbox" }
+  }
+}
+`, parentID, titleName, format)
+}
+
+func TestAccNotionDatabaseResourceRelation(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionDatabaseArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotionDatabaseResourceRelationConfig(parentID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_database.tasks", "properties.Project.relation.type", "dual_property"),
+					resource.TestCheckResourceAttrPair("yoloexp_notion_database.tasks", "properties.Project.relation.database_id", "yoloexp_notion_database.projects", "id"),
+					resource.TestCheckResourceAttrSet("yoloexp_notion_database.tasks", "properties.Project.relation.synced_property_name"),
+					resource.TestCheckResourceAttrSet("yoloexp_notion_database.tasks", "properties.Project.relation.synced_property_id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.tasks", "properties.Project count.rollup.function", "count"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database.tasks", "properties.Label.formula.expression", `prop("Name") + " (" + format(prop("Project count")) + ")"`),
+					// The synced property stays out of the related database.
+					resource.TestCheckResourceAttr("yoloexp_notion_database.projects", "properties.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNotionDatabaseResourceRelationConfig(parentID string) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_database" "projects" {
+  parent_id = %[1]q
+  title     = "Acceptance test projects"
+
+  properties = {
+    Name = { type = "title" }
+  }
+}
+
+resource "yoloexp_notion_database" "tasks" {
+  parent_id = %[1]q
+  title     = "Acceptance test tasks"
+
+  properties = {
+    Name = { type = "title" }
+    Project = {
+      type = "relation"
+      relation = {
+        database_id = yoloexp_notion_database.projects.id
+        type        = "dual_property"
+      }
+    }
+    "Project count" = {
+      type   = "rollup"
+      rollup = { relation_property = "Project", rollup_property = "Name", function = "count" }
+    }
+    Label = {
+      type    = "formula"
+      formula = { expression = "prop(\"Name\") + \" (\" + format(prop(\"Project count\")) + \")\"" }
+    }
+  }
+}
+`, parentID)
+}
+
+// testAccCheckNotionDatabaseArchived verifies that destroyed databases were
+// moved to the trash.
+func testAccCheckNotionDatabaseArchived(s *terraform.State) error {
+	client := notionapi.NewClient(notionapi.Token(os.Getenv("NOTION_SECRET")))
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "yoloexp_notion_database" {
+			continue
+		}
+
+		db, err := client.Database.Get(context.Background(), notionapi.DatabaseID(rs.Primary.ID))
+		if err != nil {
+			if isNotionNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !db.Archived {
+			return fmt.Errorf("database %s is not archived", rs.Primary.ID)
+		}
+	}
+	return nil
+}
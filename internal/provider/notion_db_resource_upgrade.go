@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionDatabaseResourceModelV0 is the state of the database resource before
+// properties were keyed by name.
+type notionDatabaseResourceModelV0 struct {
+	ID                  types.String                            `tfsdk:"id"`
+	URL                 types.String                            `tfsdk:"url"`
+	ParentID            types.String                            `tfsdk:"parent_id"`
+	ParentType          types.String                            `tfsdk:"parent_type"`
+	CurrentParentID     types.String                            `tfsdk:"current_parent_id"`
+	CurrentParentType   types.String                            `tfsdk:"current_parent_type"`
+	CreatedTime         types.String                            `tfsdk:"created_time"`
+	Title               types.String                            `tfsdk:"title"`
+	TitleRichText       []notionRichTextModel                   `tfsdk:"title_rich_text"`
+	Description         types.String                            `tfsdk:"description"`
+	DescriptionRichText []notionRichTextModel                   `tfsdk:"description_rich_text"`
+	IsInline            types.Bool                              `tfsdk:"is_inline"`
+	Icon                *notionIconModel                        `tfsdk:"icon"`
+	Cover               *notionCoverModel                       `tfsdk:"cover"`
+	Properties          []notionDatabaseResourcePropertyModelV0 `tfsdk:"properties"`
+	OnDestroy           types.String                            `tfsdk:"on_destroy"`
+}
+
+// notionDatabaseResourcePropertyModelV0 is a property in the version 0 state,
+// which carried its name as an attribute.
+type notionDatabaseResourcePropertyModelV0 struct {
+	ID           types.String               `tfsdk:"id"`
+	Name         types.String               `tfsdk:"name"`
+	PreviousName types.String               `tfsdk:"previous_name"`
+	Type         types.String               `tfsdk:"type"`
+	Number       *notionNumberConfigModel   `tfsdk:"number"`
+	Options      []notionSelectOptionModel  `tfsdk:"options"`
+	Relation     *notionRelationConfigModel `tfsdk:"relation"`
+	Rollup       *notionRollupConfigModel   `tfsdk:"rollup"`
+	Formula      *notionFormulaConfigModel  `tfsdk:"formula"`
+}
+
+// UpgradeState upgrades the state of previous schema versions.
+func (r *notionDatabaseResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+
+	// Version 0 listed the properties with their names. Attributes that were
+	// added later decode as null.
+	v0 := current.Schema
+	v0.Version = 0
+	v0.Attributes = make(map[string]schema.Attribute, len(current.Schema.Attributes))
+	for name, attr := range current.Schema.Attributes {
+		v0.Attributes[name] = attr
+	}
+	propertyAttributes := databasePropertyResourceAttributes()
+	propertyAttributes["name"] = schema.StringAttribute{Required: true}
+	v0.Attributes["properties"] = schema.ListNestedAttribute{
+		Required: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: propertyAttributes,
+		},
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &v0,
+			StateUpgrader: upgradeDatabaseStateV0,
+		},
+	}
+}
+
+// upgradeDatabaseStateV0 keys the properties of a version 0 state by name.
+func upgradeDatabaseStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior notionDatabaseResourceModelV0
+	diags := req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := notionDatabaseResourceModel{
+		ID:                  prior.ID,
+		URL:                 prior.URL,
+		ParentID:            prior.ParentID,
+		ParentType:          prior.ParentType,
+		CurrentParentID:     prior.CurrentParentID,
+		CurrentParentType:   prior.CurrentParentType,
+		CreatedTime:         prior.CreatedTime,
+		Title:               prior.Title,
+		TitleRichText:       prior.TitleRichText,
+		Description:         prior.Description,
+		DescriptionRichText: prior.DescriptionRichText,
+		IsInline:            prior.IsInline,
+		Icon:                prior.Icon,
+		Cover:               prior.Cover,
+		Properties:          make(map[string]notionDatabaseResourcePropertyModel, len(prior.Properties)),
+		OnDestroy:           prior.OnDestroy,
+	}
+	for _, p := range prior.Properties {
+		// Properties that can't be declared would be deleted by the next
+		// apply, leave them out.
+		if !isManagedPropertyType(notionapi.PropertyConfigType(p.Type.ValueString())) {
+			continue
+		}
+		state.Properties[p.Name.ValueString()] = notionDatabaseResourcePropertyModel(p)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNotionDatabaseResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &notionDatabaseResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, &notionDatabaseResourceModelV0{
+		ID:       types.StringValue("db"),
+		ParentID: types.StringValue("page"),
+		Properties: []notionDatabaseResourcePropertyModelV0{
+			{Name: types.StringValue("Name"), Type: types.StringValue("title")},
+			{Name: types.StringValue("Budget"), Type: types.StringValue("number"), Number: &notionNumberConfigModel{Format: types.StringValue("euro")}},
+			{Name: types.StringValue("Status"), Type: types.StringValue("status")},
+		},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: current.Schema,
+			Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var state notionDatabaseResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(state.Properties) != 2 {
+		t.Fatalf("upgraded state has %d properties, want 2 (status left out)", len(state.Properties))
+	}
+	if got := state.Properties["Budget"].Number; got == nil || got.Format.ValueString() != "euro" {
+		t.Errorf("Budget number settings = %+v, want format euro", got)
+	}
+	if got := state.Properties["Name"].Type.ValueString(); got != "title" {
+		t.Errorf("Name type = %q, want title", got)
+	}
+}
@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jomei/notionapi"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &notionDatabaseRowsResource{}
+	_ resource.ResourceWithConfigure      = &notionDatabaseRowsResource{}
+	_ resource.ResourceWithValidateConfig = &notionDatabaseRowsResource{}
+	_ resource.ResourceWithModifyPlan     = &notionDatabaseRowsResource{}
+)
+
+// notionRowKeyTypes are the types of the properties that can key rows.
+var notionRowKeyTypes = []notionapi.PropertyConfigType{
+	notionapi.PropertyConfigTypeTitle,
+	notionapi.PropertyConfigTypeRichText,
+	notionapi.PropertyConfigTypeURL,
+	notionapi.PropertyConfigTypeEmail,
+	notionapi.PropertyConfigTypePhoneNumber,
+	notionapi.PropertyConfigTypeSelect,
+	notionapi.PropertyConfigStatus,
+}
+
+type notionDatabaseRowsResourceModel struct {
+	ID          types.String                      `tfsdk:"id"`
+	DatabaseID  types.String                      `tfsdk:"database_id"`
+	KeyProperty types.String                      `tfsdk:"key_property"`
+	Rows        map[string]notionDatabaseRowModel `tfsdk:"rows"`
+}
+
+type notionDatabaseRowModel struct {
+	ID         types.String                            `tfsdk:"id"`
+	URL        types.String                            `tfsdk:"url"`
+	Properties map[string]notionPagePropertyValueModel `tfsdk:"properties"`
+}
+
+// NewNotionDatabaseRowsResource is a helper function to simplify the provider implementation.
+func NewNotionDatabaseRowsResource() resource.Resource {
+	return &notionDatabaseRowsResource{}
+}
+
+// notionDatabaseRowsResource is the resource implementation.
+type notionDatabaseRowsResource struct {
+	client *notionClient
+}
+
+// Metadata returns the resource type name.
+func (r *notionDatabaseRowsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notion_database_rows"
+}
+
+// Schema defines the schema for the resource.
+func (r *notionDatabaseRowsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A set of rows of a Notion database, keyed by the value of a unique property. " +
+			"Rows of the database with a key that is not listed are left alone; listing the key of such a row adopts it. Removed rows are moved to the trash.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the database.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the database the rows belong to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_property": schema.StringAttribute{
+				MarkdownDescription: "The name of the property whose value identifies a row, one of type `title`, `rich_text`, `url`, `email`, `phone_number`, `select` or `status`. Keys must be unique within the database.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rows": schema.MapNestedAttribute{
+				MarkdownDescription: "The rows, keyed by the value of their key property.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the row's page.",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "The url of the row's page.",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"properties": schema.MapNestedAttribute{
+							MarkdownDescription: "The property values of the row other than its key, as in the `properties` of `yoloexp_notion_page`. Properties that are not listed are left alone.",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: pagePropertyValueAttributes(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates that every property sets one value and that the
+// key is not set as a property.
+func (r *notionDatabaseRowsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if unknownModelValues(req.Config.Raw, "rows") {
+		// Validated once the values are known.
+		return
+	}
+
+	var config notionDatabaseRowsResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for key, row := range config.Rows {
+		p := path.Root("rows").AtMapKey(key).AtName("properties")
+		resp.Diagnostics.Append(validatePagePropertyValueCounts(row.Properties, p)...)
+		if _, ok := row.Properties[config.KeyProperty.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				p.AtMapKey(config.KeyProperty.ValueString()),
+				"Unexpected key property",
+				fmt.Sprintf("Property %q is the key property; its value is the key of the row.", config.KeyProperty.ValueString()),
+			)
+		}
+	}
+}
+
+// ModifyPlan checks the key property and the row values against the schema
+// of the database.
+func (r *notionDatabaseRowsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to do on destroy.
+		return
+	}
+	if unknownModelValues(req.Plan.Raw, "rows") {
+		// The computed values stay unknown until the values they derive
+		// from are known.
+		return
+	}
+
+	var plan notionDatabaseRowsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil || plan.DatabaseID.IsUnknown() || plan.KeyProperty.IsUnknown() {
+		return
+	}
+
+	db, err := getDatabase(ctx, r.client, notionapi.DatabaseID(plan.DatabaseID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read database",
+			fmt.Sprintf("Failed to get database to validate the rows: %s", err),
+		)
+		return
+	}
+	if _, err := rowKeyType(db, plan.KeyProperty.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("key_property"), "Invalid key property", err.Error())
+		return
+	}
+	for key, row := range plan.Rows {
+		resp.Diagnostics.Append(validatePagePropertyValues(row.Properties, path.Root("rows").AtMapKey(key).AtName("properties"), db)...)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *notionDatabaseRowsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan notionDatabaseRowsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows := plan.Rows
+	plan.ID = plan.DatabaseID
+	plan.Rows = map[string]notionDatabaseRowModel{}
+	r.applyRows(ctx, &plan, rows, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() && len(plan.Rows) == 0 {
+		return
+	}
+
+	// Rows that failed are reported as errors, the created rows are still
+	// saved so they aren't lost.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *notionDatabaseRowsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state notionDatabaseRowsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pages, err := queryDatabasePages(ctx, r.client, notionapi.DatabaseID(state.DatabaseID.ValueString()))
+	if err != nil {
+		if isNotionNotFound(err) {
+			tflog.Warn(ctx, "Database not found, removing its rows from state", map[string]any{"id": state.DatabaseID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to read rows",
+			fmt.Sprintf("Failed to query database: %s", err),
+		)
+		return
+	}
+	byID := make(map[string]*notionapi.Page, len(pages))
+	for i := range pages {
+		byID[pages[i].ID.String()] = &pages[i]
+	}
+
+	keyProperty := state.KeyProperty.ValueString()
+	for key, row := range state.Rows {
+		page, ok := byID[row.ID.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, "Row not found, removing it from state", map[string]any{"key": key, "id": row.ID.ValueString()})
+			delete(state.Rows, key)
+			continue
+		}
+		if k, _ := pagePropertyText(page.Properties[keyProperty]); k != key {
+			// The row was rekeyed outside of Terraform and is no longer the
+			// row with this key.
+			tflog.Warn(ctx, "Row key changed, removing it from state", map[string]any{"key": key, "new_key": k})
+			delete(state.Rows, key)
+			continue
+		}
+		state.Rows[key] = flattenRow(page, row)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *notionDatabaseRowsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state notionDatabaseRowsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows := plan.Rows
+	plan.Rows = map[string]notionDatabaseRowModel{}
+	r.applyRows(ctx, &plan, rows, state.Rows, &resp.Diagnostics)
+
+	// Archive the rows that were removed. Rows that fail to be archived are
+	// kept in state.
+	for _, key := range sortedRowKeys(state.Rows) {
+		if _, ok := rows[key]; ok {
+			continue
+		}
+		if err := archivePage(ctx, r.client, state.Rows[key].ID.ValueString()); err != nil {
+			plan.Rows[key] = state.Rows[key]
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rows").AtMapKey(key),
+				"Failed to delete row",
+				fmt.Sprintf("Failed to archive row %q: %s", key, err),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *notionDatabaseRowsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state notionDatabaseRowsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, key := range sortedRowKeys(state.Rows) {
+		if err := archivePage(ctx, r.client, state.Rows[key].ID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rows").AtMapKey(key),
+				"Failed to delete row",
+				fmt.Sprintf("Failed to archive row %q: %s", key, err),
+			)
+		}
+	}
+}
+
+func (r *notionDatabaseRowsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*notionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// applyRows creates or updates the planned rows, given the rows in state, nil
+// on create, and records the rows that were applied in model. A row that
+// fails is reported with an error and keeps its prior state, or is left out
+// when it is new, so that the other rows are still applied. prior isn't
+// modified.
+func (r *notionDatabaseRowsResource) applyRows(ctx context.Context, model *notionDatabaseRowsResourceModel, rows, prior map[string]notionDatabaseRowModel, diags *diag.Diagnostics) {
+	dbID := notionapi.DatabaseID(model.DatabaseID.ValueString())
+	db, err := getDatabase(ctx, r.client, dbID)
+	if err != nil {
+		diags.AddError(
+			"Failed to read database",
+			fmt.Sprintf("Failed to get database: %s", err),
+		)
+		model.Rows = maps.Clone(prior)
+		return
+	}
+	keyProperty := model.KeyProperty.ValueString()
+	keyType, err := rowKeyType(db, keyProperty)
+	if err != nil {
+		diags.AddAttributeError(path.Root("key_property"), "Invalid key property", err.Error())
+		model.Rows = maps.Clone(prior)
+		return
+	}
+
+	// New rows adopt the existing row with the same key, if any.
+	var existing map[string]*notionapi.Page
+	if !rowKeysIn(rows, prior) {
+		pages, err := queryDatabasePages(ctx, r.client, dbID)
+		if err != nil {
+			diags.AddError(
+				"Failed to read rows",
+				fmt.Sprintf("Failed to query database: %s", err),
+			)
+			model.Rows = maps.Clone(prior)
+			return
+		}
+		existing = make(map[string]*notionapi.Page, len(pages))
+		for i := range pages {
+			k, ok := pagePropertyText(pages[i].Properties[keyProperty])
+			if !ok || k == "" {
+				continue
+			}
+			if _, dup := existing[k]; dup {
+				diags.AddAttributeWarning(
+					path.Root("rows").AtMapKey(k),
+					"Duplicate row key",
+					fmt.Sprintf("More than one row of the database has the key %q; the first one is used.", k),
+				)
+				continue
+			}
+			existing[k] = &pages[i]
+		}
+	}
+
+	for _, key := range sortedRowKeys(rows) {
+		row := rows[key]
+		p := path.Root("rows").AtMapKey(key)
+
+		old, ok := prior[key]
+		if !ok {
+			if page := existing[key]; page != nil {
+				// Adopt the row; all of its configured values are written.
+				old = notionDatabaseRowModel{ID: types.StringValue(page.ID.String()), URL: types.StringValue(page.URL)}
+				ok = true
+			}
+		}
+
+		if !ok {
+			props := expandPagePropertyValues(row.Properties, nil)
+			props[keyProperty] = expandPagePropertyValue(rowKeyValue(keyType, key))
+			page, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+				Parent:     expandParent(string(notionapi.ParentTypeDatabaseID), dbID.String()),
+				Properties: props,
+			})
+			if err != nil {
+				diags.AddAttributeError(p, "Failed to create row", fmt.Sprintf("Failed to create row %q: %s", key, err))
+				continue
+			}
+			model.Rows[key] = flattenRow(page, row)
+			continue
+		}
+
+		props := expandPagePropertyValues(row.Properties, old.Properties)
+		if len(props) == 0 {
+			row.ID, row.URL = old.ID, old.URL
+			model.Rows[key] = row
+			continue
+		}
+		page, err := r.client.Page.Update(ctx, notionapi.PageID(old.ID.ValueString()), &notionapi.PageUpdateRequest{
+			Properties: props,
+		})
+		if err != nil {
+			diags.AddAttributeError(p, "Failed to update row", fmt.Sprintf("Failed to update row %q: %s", key, err))
+			if _, managed := prior[key]; managed {
+				model.Rows[key] = old
+			}
+			continue
+		}
+		model.Rows[key] = flattenRow(page, row)
+	}
+}
+
+// flattenRow returns the state of a row given its page and its prior state.
+func flattenRow(page *notionapi.Page, prior notionDatabaseRowModel) notionDatabaseRowModel {
+	row := notionDatabaseRowModel{
+		ID:  types.StringValue(page.ID.String()),
+		URL: types.StringValue(page.URL),
+	}
+	if prior.Properties != nil {
+		row.Properties = make(map[string]notionPagePropertyValueModel, len(prior.Properties))
+		for name, v := range prior.Properties {
+			if p, ok := page.Properties[name]; ok {
+				row.Properties[name] = flattenPagePropertyValue(p, v)
+			}
+		}
+	}
+	return row
+}
+
+// rowKeyType returns the type of the key property of a database.
+func rowKeyType(db *notionDatabase, name string) (notionapi.PropertyConfigType, error) {
+	config, ok := db.Properties[name]
+	if !ok {
+		return "", fmt.Errorf("the database has no property %q", name)
+	}
+	for _, t := range notionRowKeyTypes {
+		if config.GetType() == t {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("property %q is of type %q, which can't key rows", name, config.GetType())
+}
+
+// rowKeyValue returns the property value that sets a key.
+func rowKeyValue(t notionapi.PropertyConfigType, key string) notionPagePropertyValueModel {
+	v := newPagePropertyValue()
+	k := types.StringValue(key)
+	switch t {
+	case notionapi.PropertyConfigTypeTitle:
+		v.Title = k
+	case notionapi.PropertyConfigTypeRichText:
+		v.RichText = k
+	case notionapi.PropertyConfigTypeURL:
+		v.URL = k
+	case notionapi.PropertyConfigTypeEmail:
+		v.Email = k
+	case notionapi.PropertyConfigTypePhoneNumber:
+		v.PhoneNumber = k
+	case notionapi.PropertyConfigTypeSelect:
+		v.Select = k
+	case notionapi.PropertyConfigStatus:
+		v.Status = k
+	}
+	return v
+}
+
+// pagePropertyText returns the value of a text-like page property, such as a
+// title or a select, as plain text.
+func pagePropertyText(p notionapi.Property) (string, bool) {
+	switch p := p.(type) {
+	case *notionapi.TitleProperty:
+		return richTextPlainText(p.Title), true
+	case *notionapi.RichTextProperty:
+		return richTextPlainText(p.RichText), true
+	case *notionapi.URLProperty:
+		return p.URL, true
+	case *notionapi.EmailProperty:
+		return p.Email, true
+	case *notionapi.PhoneNumberProperty:
+		return p.PhoneNumber, true
+	case *notionapi.SelectProperty:
+		return p.Select.Name, true
+	case *notionapi.StatusProperty:
+		return p.Status.Name, true
+	}
+	return "", false
+}
+
+// rowKeysIn reports whether all the keys of rows are keys of prior.
+func rowKeysIn(rows, prior map[string]notionDatabaseRowModel) bool {
+	for k := range rows {
+		if _, ok := prior[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedRowKeys(rows map[string]notionDatabaseRowModel) []string {
+	keys := make([]string, 0, len(rows))
+	for k := range rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionDatabaseRowsResource(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotionDatabaseRowsResourceConfig(parentID, `
+    api = { properties = { Owner = { rich_text = "team-a" }, Tier = { number = 1 } } }
+    web = { properties = { Owner = { rich_text = "team-b" }, Tier = { number = 2 } } }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_database_rows.test", "rows.%", "2"),
+					resource.TestCheckResourceAttrSet("yoloexp_notion_database_rows.test", "rows.api.id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database_rows.test", "rows.web.properties.Owner.rich_text", "team-b"),
+				),
+			},
+			{
+				Config: testAccNotionDatabaseRowsResourceConfig(parentID, `
+    api    = { properties = { Owner = { rich_text = "team-c" }, Tier = { number = 1 } } }
+    worker = { properties = { Owner = { rich_text = "team-b" }, Tier = { number = 3 } } }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_database_rows.test", "rows.%", "2"),
+					resource.TestCheckResourceAttr("yoloexp_notion_database_rows.test", "rows.api.properties.Owner.rich_text", "team-c"),
+					resource.TestCheckNoResourceAttr("yoloexp_notion_database_rows.test", "rows.web.id"),
+					resource.TestCheckResourceAttrSet("yoloexp_notion_database_rows.test", "rows.worker.id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNotionDatabaseRowsResourceConfig(parentID, rows string) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_database" "test" {
+  parent_id = %[1]q
+  title     = "Acceptance test services"
+
+  properties = {
+    Service = { type = "title" }
+    Owner   = { type = "rich_text" }
+    Tier    = { type = "number" }
+  }
+}
+
+resource "yoloexp_notion_database_rows" "test" {
+  database_id  = yoloexp_notion_database.test.id
+  key_property = "Service"
+
+  rows = {
+%[2]s
+  }
+}
+`, parentID, rows)
+}
+
+func TestRowKeyType(t *testing.T) {
+	var db notionDatabase
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"properties": {
+			"Service": {"id": "title", "type": "title", "title": {}},
+			"Tier": {"id": "tr", "type": "number", "number": {"format": "number"}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := rowKeyType(&db, "Service"); err != nil || got != notionapi.PropertyConfigTypeTitle {
+		t.Errorf("rowKeyType(Service) = %q, %v", got, err)
+	}
+	if _, err := rowKeyType(&db, "Tier"); err == nil {
+		t.Error("rowKeyType(Tier) succeeded for a number property")
+	}
+	if _, err := rowKeyType(&db, "Missing"); err == nil {
+		t.Error("rowKeyType(Missing) succeeded for an unknown property")
+	}
+
+	v := expandPagePropertyValue(rowKeyValue(notionapi.PropertyConfigTypeTitle, "api"))
+	if got, _ := pagePropertyText(&notionapi.TitleProperty{Title: v.(notionapi.TitleProperty).Title}); got != "api" {
+		t.Errorf("key round trip = %q, want api", got)
+	}
+}
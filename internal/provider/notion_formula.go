@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// formulaPropRef matches a property reference in a formula expression,
+	// e.g. prop("Due date").
+	formulaPropRef = regexp.MustCompile(`prop\(\s*"((?:[^"\\]|\\.)*)"\s*\)`)
+
+	// formulaPropToken matches a property reference as the Notion API returns
+	// it: {{notion:block_property:<property id>:...}}.
+	formulaPropToken = regexp.MustCompile(`\{\{notion:block_property:([^:}]+)[^}]*\}\}`)
+)
+
+// formulaPropertyReferences returns the names of the properties a formula
+// expression references with prop("...").
+func formulaPropertyReferences(expression string) []string {
+	var names []string
+	for _, m := range formulaPropRef.FindAllStringSubmatch(expression, -1) {
+		name, err := strconv.Unquote(`"` + m[1] + `"`)
+		if err != nil {
+			name = m[1]
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// normalizeFormulaExpression replaces the property tokens of an expression
+// returned by the Notion API with prop("...") references, given the names of
+// the database properties by id. Tokens of unknown properties are kept.
+func normalizeFormulaExpression(expression string, names map[string]string) string {
+	return formulaPropToken.ReplaceAllStringFunc(expression, func(token string) string {
+		id := formulaPropToken.FindStringSubmatch(token)[1]
+		if name, ok := names[unescapePropertyID(id)]; ok {
+			return "prop(" + strconv.Quote(name) + ")"
+		}
+		return token
+	})
+}
+
+// formulaExpressionEqual reports whether two formula expressions are equal
+// apart from whitespace.
+func formulaExpressionEqual(a, b string) bool {
+	strip := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	return strip(a) == strip(b)
+}
+
+// unescapePropertyID decodes a property id, which the Notion API returns URL
+// encoded in some places and not in others.
+func unescapePropertyID(id string) string {
+	if s, err := url.PathUnescape(id); err == nil {
+		return s
+	}
+	return id
+}
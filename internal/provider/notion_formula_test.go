@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestFormulaPropertyReferences(t *testing.T) {
+	got := formulaPropertyReferences(`if(prop("Done"), 0, dateBetween(prop( "Due date" ), now(), "days")) + prop("Say \"hi\"")`)
+	want := []string{"Done", "Due date", `Say "hi"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("formulaPropertyReferences() = %q, want %q", got, want)
+	}
+}
+
+func TestNotionDatabaseNormalizesFormulas(t *testing.T) {
+	var db notionDatabase
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"Budget": {"id": "%3AUPp", "type": "number", "number": {"format": "number"}},
+			"Double": {"id": "dbl", "type": "formula", "formula": {
+				"expression": "{{notion:block_property:%3AUPp:00000000-0000-0000-0000-000000000000:8994905a-074a-415f-9bcf-d1f8b4fa38e4}} * 2"
+			}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, ok := db.Properties["Double"].(*notionapi.FormulaPropertyConfig)
+	if !ok {
+		t.Fatalf("Double decoded as %T", db.Properties["Double"])
+	}
+	if want := `prop("Budget") * 2`; f.Formula.Expression != want {
+		t.Errorf("expression = %q, want %q", f.Formula.Expression, want)
+	}
+	if !formulaExpressionEqual(f.Formula.Expression, `prop("Budget")*2`) {
+		t.Error("formulaExpressionEqual() = false for expressions differing in whitespace")
+	}
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionNull clears a field in a Notion update request.
+var notionNull = json.RawMessage("null")
+
+type notionIconModel struct {
+	Emoji       types.String `tfsdk:"emoji"`
+	ExternalURL types.String `tfsdk:"external_url"`
+	FileURL     types.String `tfsdk:"file_url"`
+}
+
+type notionCoverModel struct {
+	ExternalURL types.String `tfsdk:"external_url"`
+	FileURL     types.String `tfsdk:"file_url"`
+}
+
+// iconResourceAttribute returns the schema of an icon attribute.
+func iconResourceAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"emoji": schema.StringAttribute{
+				MarkdownDescription: "An emoji icon. Exactly one of `emoji` and `external_url` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("external_url")),
+				},
+			},
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of an externally hosted icon image.",
+				Optional:            true,
+			},
+			"file_url": schema.StringAttribute{
+				MarkdownDescription: "The temporary URL of an icon uploaded to Notion. The Notion API can't upload files, so uploaded icons are only reported.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// coverResourceAttribute returns the schema of a cover attribute.
+func coverResourceAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of an externally hosted cover image.",
+				Required:            true,
+			},
+			"file_url": schema.StringAttribute{
+				MarkdownDescription: "The temporary URL of a cover uploaded to Notion. The Notion API can't upload files, so uploaded covers are only reported.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// expandIcon converts an icon model into a Notion icon.
+func expandIcon(m *notionIconModel) *notionapi.Icon {
+	if m == nil {
+		return nil
+	}
+	if !m.Emoji.IsNull() {
+		emoji := notionapi.Emoji(m.Emoji.ValueString())
+		return &notionapi.Icon{Type: "emoji", Emoji: &emoji}
+	}
+	return &notionapi.Icon{
+		Type:     notionapi.FileTypeExternal,
+		External: &notionapi.FileObject{URL: m.ExternalURL.ValueString()},
+	}
+}
+
+// expandCover converts a cover model into a Notion image.
+func expandCover(m *notionCoverModel) *notionapi.Image {
+	if m == nil {
+		return nil
+	}
+	return &notionapi.Image{
+		Type:     notionapi.FileTypeExternal,
+		External: &notionapi.FileObject{URL: m.ExternalURL.ValueString()},
+	}
+}
+
+// flattenIcon converts a Notion icon into an icon model.
+func flattenIcon(icon *notionapi.Icon) *notionIconModel {
+	if icon == nil {
+		return nil
+	}
+	m := &notionIconModel{
+		Emoji:       types.StringNull(),
+		ExternalURL: types.StringNull(),
+		FileURL:     types.StringNull(),
+	}
+	switch {
+	case icon.Emoji != nil:
+		m.Emoji = types.StringValue(string(*icon.Emoji))
+	case icon.External != nil:
+		m.ExternalURL = types.StringValue(icon.External.URL)
+	case icon.File != nil:
+		m.FileURL = types.StringValue(icon.File.URL)
+	default:
+		return nil
+	}
+	return m
+}
+
+// flattenCover converts a Notion image into a cover model.
+func flattenCover(cover *notionapi.Image) *notionCoverModel {
+	if cover == nil {
+		return nil
+	}
+	m := &notionCoverModel{
+		ExternalURL: types.StringNull(),
+		FileURL:     types.StringNull(),
+	}
+	switch {
+	case cover.External != nil:
+		m.ExternalURL = types.StringValue(cover.External.URL)
+	case cover.File != nil:
+		m.FileURL = types.StringValue(cover.File.URL)
+	default:
+		return nil
+	}
+	return m
+}
+
+// iconEqual reports whether two icon models configure the same icon.
+func iconEqual(a, b *notionIconModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Emoji.Equal(b.Emoji) && a.ExternalURL.Equal(b.ExternalURL)
+}
+
+// coverEqual reports whether two cover models configure the same cover.
+func coverEqual(a, b *notionCoverModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ExternalURL.Equal(b.ExternalURL)
+}
+
+// iconUpdate returns the icon field of an update request: the new icon, or
+// null to remove it.
+func iconUpdate(m *notionIconModel) any {
+	if m == nil {
+		return notionNull
+	}
+	return expandIcon(m)
+}
+
+// coverUpdate returns the cover field of an update request: the new cover, or
+// null to remove it.
+func coverUpdate(m *notionCoverModel) any {
+	if m == nil {
+		return notionNull
+	}
+	return expandCover(m)
+}
+
+// setIconCoverComputed fills the computed attributes of planned icon and cover
+// models from the icon and cover Notion returned.
+func setIconCoverComputed(icon *notionIconModel, cover *notionCoverModel, gotIcon *notionapi.Icon, gotCover *notionapi.Image) {
+	if icon != nil {
+		icon.FileURL = types.StringNull()
+		if got := flattenIcon(gotIcon); got != nil {
+			icon.FileURL = got.FileURL
+		}
+	}
+	if cover != nil {
+		cover.FileURL = types.StringNull()
+		if got := flattenCover(gotCover); got != nil {
+			cover.FileURL = got.FileURL
+		}
+	}
+}
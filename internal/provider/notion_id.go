@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// notionIDPattern matches the id at the end of a URL path segment such as
+// "Team-Tasks-8263e8303424475a801c1d971606cd6c", or a bare id.
+var notionIDPattern = regexp.MustCompile(`(?:^|-)([0-9a-fA-F]{32})$`)
+
+// parseNotionID extracts the id of a Notion object from a raw id, a UUID with
+// or without dashes, or a notion.so URL, and returns it as a dashed UUID.
+func parseNotionID(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	var id string
+	if strings.Contains(s, "/") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid Notion URL %q: %w", s, err)
+		}
+		m := notionIDPattern.FindStringSubmatch(u.Path[strings.LastIndex(u.Path, "/")+1:])
+		if m == nil {
+			return "", fmt.Errorf("URL %q doesn't contain a Notion id", s)
+		}
+		id = m[1]
+	} else {
+		m := notionIDPattern.FindStringSubmatch(strings.ReplaceAll(s, "-", ""))
+		if m == nil {
+			return "", fmt.Errorf("%q is not a Notion id", s)
+		}
+		id = m[1]
+	}
+	id = strings.ToLower(id)
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", id[0:8], id[8:12], id[12:16], id[16:20], id[20:32]), nil
+}
+
+// notionIDEqual reports whether two strings refer to the same Notion id,
+// ignoring dashes and case.
+func notionIDEqual(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "-", ""))
+	}
+	return normalize(a) == normalize(b)
+}
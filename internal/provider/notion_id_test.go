@@ -0,0 +1,32 @@
+package provider
+
+import "testing"
+
+func TestParseNotionID(t *testing.T) {
+	const want = "8263e830-3424-475a-801c-1d971606cd6c"
+
+	for _, input := range []string{
+		"8263e830-3424-475a-801c-1d971606cd6c",
+		"8263e8303424475a801c1d971606cd6c",
+		" 8263E8303424475A801C1D971606CD6C ",
+		"https://www.notion.so/8263e8303424475a801c1d971606cd6c",
+		"https://www.notion.so/acme/Team-Tasks-8263e8303424475a801c1d971606cd6c?v=0123456789abcdef0123456789abcdef",
+		"notion.so/acme/Team-Tasks-8263e8303424475a801c1d971606cd6c",
+		"https://www.notion.so/acme/Cafe-Beef-8263e8303424475a801c1d971606cd6c",
+	} {
+		got, err := parseNotionID(input)
+		if err != nil {
+			t.Errorf("parseNotionID(%q) returned error: %s", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseNotionID(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "not-an-id", "8263e830-3424-475a-801c", "https://www.notion.so/acme/Team-Tasks"} {
+		if got, err := parseNotionID(input); err == nil {
+			t.Errorf("parseNotionID(%q) = %q, want error", input, got)
+		}
+	}
+}
@@ -0,0 +1,627 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jomei/notionapi"
+)
+
+// notionRichTextLimit is the maximum number of characters of a single rich
+// text object.
+const notionRichTextLimit = 2000
+
+var (
+	mdHeading  = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*)$`)
+	mdDivider  = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdFence    = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	mdQuote    = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdListItem = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(?:\s+(.*))?$`)
+	mdToDo     = regexp.MustCompile(`^\[([ xX])\](?:\s+(.*))?$`)
+
+	// mdContainer matches the opening tag of the HTML elements that hold
+	// blocks: toggles, callouts and column layouts.
+	mdContainer = regexp.MustCompile(`^\s{0,3}<(details|aside|columns)>\s*(.*)$`)
+	mdSummary   = regexp.MustCompile(`^\s*<summary>(.*)</summary>\s*$`)
+)
+
+// notionDefaultCalloutIcon is the icon Notion gives callouts without one.
+const notionDefaultCalloutIcon = "💡"
+
+// notionCodeLanguages maps common fenced code block info strings to the
+// languages Notion supports. Names Notion supports as is are not listed.
+var notionCodeLanguages = map[string]string{
+	"":           "plain text",
+	"text":       "plain text",
+	"plaintext":  "plain text",
+	"sh":         "shell",
+	"zsh":        "shell",
+	"console":    "shell",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"golang":     "go",
+	"yml":        "yaml",
+	"md":         "markdown",
+	"dockerfile": "docker",
+	"cpp":        "c++",
+	"cs":         "c#",
+	"csharp":     "c#",
+	"fsharp":     "f#",
+	"kt":         "kotlin",
+	"ps1":        "powershell",
+	"proto":      "protobuf",
+	"make":       "makefile",
+	"objc":       "objective-c",
+	"html":       "html",
+	"hcl":        "plain text",
+	"tf":         "plain text",
+	"terraform":  "plain text",
+}
+
+// notionSupportedCodeLanguages lists the code block languages Notion accepts.
+var notionSupportedCodeLanguages = []string{
+	"abap", "arduino", "bash", "basic", "c", "clojure", "coffeescript", "c++", "c#", "css", "dart", "diff", "docker",
+	"elixir", "elm", "erlang", "flow", "fortran", "f#", "gherkin", "glsl", "go", "graphql", "groovy", "haskell", "html",
+	"java", "javascript", "json", "julia", "kotlin", "latex", "less", "lisp", "livescript", "lua", "makefile", "markdown",
+	"markup", "matlab", "mermaid", "nix", "objective-c", "ocaml", "pascal", "perl", "php", "plain text", "powershell",
+	"prolog", "protobuf", "python", "r", "reason", "ruby", "rust", "sass", "scala", "scheme", "scss", "shell", "sql",
+	"swift", "typescript", "vb.net", "verilog", "vhdl", "visual basic", "webassembly", "xml", "yaml", "java/c/c++/c#",
+}
+
+// markdownToBlocks converts Markdown into Notion blocks. It understands
+// headings, paragraphs, bulleted, numbered and to-do lists (nested by
+// indentation), quotes, fenced code, dividers, and inline links, bold,
+// italic, strikethrough, code and <u>underline</u>. Toggles, callouts and
+// column layouts are written as <details>, <aside> and <columns> elements
+// holding Markdown. Anything else is kept as paragraph text.
+func markdownToBlocks(md string) []notionapi.Block {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	md = strings.ReplaceAll(md, "\t", "    ")
+	return parseMarkdownBlocks(strings.Split(md, "\n"))
+}
+
+func parseMarkdownBlocks(lines []string) []notionapi.Block {
+	blocks := []notionapi.Block{}
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, paragraphBlock(strings.Join(para, "\n")))
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isBlankLine(line):
+			flush()
+
+		case mdFence.MatchString(line):
+			flush()
+			m := mdFence.FindStringSubmatch(line)
+			indent := leadingSpaces(line)
+			var code []string
+			for i++; i < len(lines) && !isClosingFence(lines[i], m[1]); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], strings.Repeat(" ", min(indent, leadingSpaces(lines[i])))))
+			}
+			blocks = append(blocks, codeBlock(strings.Join(code, "\n"), m[2]))
+
+		case mdContainer.MatchString(line):
+			flush()
+			m := mdContainer.FindStringSubmatch(line)
+			var body []string
+			body, i = containerLines(lines, i, m[1])
+			switch m[1] {
+			case "details":
+				blocks = append(blocks, toggleBlock(m[2], body))
+			case "aside":
+				blocks = append(blocks, calloutBlock(body))
+			case "columns":
+				blocks = append(blocks, columnListBlock(body))
+			}
+
+		case mdHeading.MatchString(line):
+			flush()
+			m := mdHeading.FindStringSubmatch(line)
+			blocks = append(blocks, headingBlock(len(m[1]), strings.TrimSpace(m[2])))
+
+		case mdDivider.MatchString(line):
+			flush()
+			blocks = append(blocks, &notionapi.DividerBlock{
+				BasicBlock: newBasicBlock(notionapi.BlockTypeDivider),
+				Divider:    notionapi.Divider{},
+			})
+
+		case mdQuote.MatchString(line):
+			flush()
+			var quote []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				quote = append(quote, strings.TrimSpace(mdQuote.FindStringSubmatch(lines[i])[1]))
+			}
+			i--
+			blocks = append(blocks, &notionapi.QuoteBlock{
+				BasicBlock: newBasicBlock(notionapi.BlockQuote),
+				Quote:      notionapi.Quote{RichText: markdownRichText(strings.Join(quote, "\n"))},
+			})
+
+		case mdListItem.MatchString(line):
+			flush()
+			m := mdListItem.FindStringSubmatch(line)
+			indent := len(m[1])
+
+			// The item owns the lines indented deeper than its marker, and
+			// the blank lines between them.
+			var nested []string
+			j := i + 1
+			for ; j < len(lines); j++ {
+				if isBlankLine(lines[j]) {
+					k := j
+					for k < len(lines) && isBlankLine(lines[k]) {
+						k++
+					}
+					if k == len(lines) || leadingSpaces(lines[k]) <= indent {
+						break
+					}
+					nested = append(nested, "")
+					continue
+				}
+				if leadingSpaces(lines[j]) <= indent {
+					break
+				}
+				nested = append(nested, lines[j])
+			}
+			i = j - 1
+
+			// Indented text right below the item continues the item's text.
+			nested = dedentLines(nested)
+			text := []string{m[3]}
+			for len(nested) > 0 && !isBlankLine(nested[0]) && !startsMarkdownBlock(nested[0]) {
+				text = append(text, strings.TrimSpace(nested[0]))
+				nested = nested[1:]
+			}
+
+			var children []notionapi.Block
+			if len(nested) > 0 {
+				children = parseMarkdownBlocks(nested)
+			}
+			blocks = append(blocks, listItemBlock(m[2], strings.Join(text, "\n"), children))
+
+		default:
+			para = append(para, strings.TrimSpace(line))
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// startsMarkdownBlock reports whether a line starts a block other than a
+// paragraph.
+func startsMarkdownBlock(line string) bool {
+	return mdFence.MatchString(line) || mdHeading.MatchString(line) || mdDivider.MatchString(line) ||
+		mdQuote.MatchString(line) || mdListItem.MatchString(line) || mdContainer.MatchString(line)
+}
+
+// containerLines returns the lines inside the element of the given tag that
+// opens at lines[i], and the index of its closing tag. Elements of the same
+// tag can be nested; an element that is never closed ends with the input.
+func containerLines(lines []string, i int, tag string) ([]string, int) {
+	open, end := "<"+tag+">", "</"+tag+">"
+	depth := 1
+	for j := i + 1; j < len(lines); j++ {
+		switch l := strings.TrimSpace(lines[j]); {
+		case strings.HasPrefix(l, open):
+			depth++
+		case l == end:
+			depth--
+			if depth == 0 {
+				return lines[i+1 : j], j
+			}
+		}
+	}
+	return lines[i+1:], len(lines)
+}
+
+// toggleBlock returns the toggle of a <details> element. The summary is the
+// toggle's text, on the line of the opening tag or the line after it.
+func toggleBlock(rest string, body []string) notionapi.Block {
+	summary := ""
+	if m := mdSummary.FindStringSubmatch(rest); m != nil {
+		summary = m[1]
+	} else if len(body) > 0 {
+		if m := mdSummary.FindStringSubmatch(body[0]); m != nil {
+			summary = m[1]
+			body = body[1:]
+		}
+	}
+	toggle := &notionapi.ToggleBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeToggle),
+		Toggle:     notionapi.Toggle{RichText: markdownRichText(strings.TrimSpace(summary))},
+	}
+	if children := parseMarkdownBlocks(dedentLines(body)); len(children) > 0 {
+		toggle.Toggle.Children = children
+	}
+	return toggle
+}
+
+// calloutBlock returns the callout of an <aside> element. Its first paragraph
+// is the callout's text, and an emoji starting it the callout's icon.
+func calloutBlock(body []string) notionapi.Block {
+	emoji := notionapi.Emoji(notionDefaultCalloutIcon)
+	callout := &notionapi.CalloutBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockCallout),
+		Callout: notionapi.Callout{
+			RichText: []notionapi.RichText{},
+			Icon:     &notionapi.Icon{Type: "emoji", Emoji: &emoji},
+		},
+	}
+	children := parseMarkdownBlocks(dedentLines(body))
+	if len(children) > 0 {
+		if p, ok := children[0].(*notionapi.ParagraphBlock); ok {
+			callout.Callout.RichText = p.Paragraph.RichText
+			children = children[1:]
+		}
+	}
+	if rt := callout.Callout.RichText; len(rt) > 0 && rt[0].Text != nil {
+		if icon, rest, ok := strings.Cut(rt[0].Text.Content, " "); ok && isEmoji(icon) {
+			emoji = notionapi.Emoji(icon)
+			rt[0].Text.Content = rest
+		}
+	}
+	if len(children) > 0 {
+		callout.Callout.Children = children
+	}
+	return callout
+}
+
+// isEmoji reports whether s is made of symbols outside ASCII, as emojis are.
+func isEmoji(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < utf8.RuneSelf || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnListBlock returns the column layout of a <columns> element, which
+// holds a <column> element for each column.
+func columnListBlock(body []string) notionapi.Block {
+	columns := []notionapi.Block{}
+	for i := 0; i < len(body); i++ {
+		if strings.TrimSpace(body[i]) != "<column>" {
+			continue
+		}
+		var lines []string
+		lines, i = containerLines(body, i, "column")
+		children := parseMarkdownBlocks(dedentLines(lines))
+		if len(children) == 0 {
+			// Notion doesn't allow empty columns.
+			children = append(children, paragraphBlock(""))
+		}
+		columns = append(columns, &notionapi.ColumnBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeColumn),
+			Column:     notionapi.Column{Children: children},
+		})
+	}
+	return &notionapi.ColumnListBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeColumnList),
+		ColumnList: notionapi.ColumnList{Children: columns},
+	}
+}
+
+func isClosingFence(line, fence string) bool {
+	s := strings.TrimSpace(line)
+	return strings.HasPrefix(s, fence) && strings.Trim(s, fence[:1]) == ""
+}
+
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// dedentLines removes the indentation common to all non-blank lines.
+func dedentLines(lines []string) []string {
+	indent := -1
+	for _, l := range lines {
+		if !isBlankLine(l) && (indent < 0 || leadingSpaces(l) < indent) {
+			indent = leadingSpaces(l)
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if !isBlankLine(l) {
+			out[i] = l[indent:]
+		}
+	}
+	return out
+}
+
+func newBasicBlock(t notionapi.BlockType) notionapi.BasicBlock {
+	return notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: t}
+}
+
+func paragraphBlock(text string) notionapi.Block {
+	return &notionapi.ParagraphBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeParagraph),
+		Paragraph:  notionapi.Paragraph{RichText: markdownRichText(text)},
+	}
+}
+
+// headingBlock returns a heading of the given level. Notion has three levels
+// of headings, deeper levels become the third one.
+func headingBlock(level int, text string) notionapi.Block {
+	heading := notionapi.Heading{RichText: markdownRichText(text)}
+	switch level {
+	case 1:
+		return &notionapi.Heading1Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading1), Heading1: heading}
+	case 2:
+		return &notionapi.Heading2Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading2), Heading2: heading}
+	default:
+		return &notionapi.Heading3Block{BasicBlock: newBasicBlock(notionapi.BlockTypeHeading3), Heading3: heading}
+	}
+}
+
+func codeBlock(code, info string) notionapi.Block {
+	return &notionapi.CodeBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeCode),
+		Code: notionapi.Code{
+			RichText: splitRichText(code, nil),
+			Language: notionCodeLanguage(info),
+		},
+	}
+}
+
+// notionCodeLanguage returns the Notion language of a fenced code block info
+// string, plain text if Notion does not know the language.
+func notionCodeLanguage(info string) string {
+	lang := strings.ToLower(info)
+	if l, ok := notionCodeLanguages[lang]; ok {
+		return l
+	}
+	for _, l := range notionSupportedCodeLanguages {
+		if l == lang {
+			return l
+		}
+	}
+	return "plain text"
+}
+
+// listItemBlock returns a bulleted, numbered or to-do list item for a list
+// marker and the text following it.
+func listItemBlock(marker, text string, children []notionapi.Block) notionapi.Block {
+	if strings.ContainsAny(marker, ".)") {
+		return &notionapi.NumberedListItemBlock{
+			BasicBlock:       newBasicBlock(notionapi.BlockTypeNumberedListItem),
+			NumberedListItem: notionapi.ListItem{RichText: markdownRichText(text), Children: children},
+		}
+	}
+	if m := mdToDo.FindStringSubmatch(text); m != nil {
+		return &notionapi.ToDoBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeToDo),
+			ToDo: notionapi.ToDo{
+				RichText: markdownRichText(m[2]),
+				Checked:  m[1] != " ",
+				Children: children,
+			},
+		}
+	}
+	return &notionapi.BulletedListItemBlock{
+		BasicBlock:       newBasicBlock(notionapi.BlockTypeBulletedListItem),
+		BulletedListItem: notionapi.ListItem{RichText: markdownRichText(text), Children: children},
+	}
+}
+
+// mdStyle is the formatting of a run of inline Markdown text.
+type mdStyle struct {
+	bold, italic, strikethrough, underline, code bool
+	link                                         string
+}
+
+type mdSegment struct {
+	text  string
+	style mdStyle
+}
+
+// markdownRichText converts inline Markdown into Notion rich text.
+func markdownRichText(text string) []notionapi.RichText {
+	rt := []notionapi.RichText{}
+	segments := parseInlineMarkdown(text, mdStyle{})
+	for i := 0; i < len(segments); i++ {
+		s := segments[i]
+		for i+1 < len(segments) && segments[i+1].style == s.style {
+			s.text += segments[i+1].text
+			i++
+		}
+		rt = append(rt, splitRichText(s.text, &s.style)...)
+	}
+	return rt
+}
+
+// splitRichText returns text as rich text objects of the given style, split
+// to respect the length limit of rich text objects.
+func splitRichText(text string, style *mdStyle) []notionapi.RichText {
+	rt := []notionapi.RichText{}
+	for text != "" {
+		chunk := text
+		if utf8.RuneCountInString(chunk) > notionRichTextLimit {
+			n := 0
+			for i := range chunk {
+				if n == notionRichTextLimit {
+					chunk = chunk[:i]
+					break
+				}
+				n++
+			}
+		}
+		text = text[len(chunk):]
+
+		r := notionapi.RichText{
+			Type: notionapi.ObjectTypeText,
+			Text: &notionapi.Text{Content: chunk},
+		}
+		if style != nil {
+			if style.link != "" {
+				r.Text.Link = &notionapi.Link{Url: style.link}
+			}
+			r.Annotations = &notionapi.Annotations{
+				Bold:          style.bold,
+				Italic:        style.italic,
+				Strikethrough: style.strikethrough,
+				Underline:     style.underline,
+				Code:          style.code,
+				Color:         notionapi.ColorDefault,
+			}
+		}
+		rt = append(rt, r)
+	}
+	return rt
+}
+
+// parseInlineMarkdown splits inline Markdown into runs of text of the same
+// style. Delimiters without a match are kept as text.
+func parseInlineMarkdown(s string, style mdStyle) []mdSegment {
+	var segments []mdSegment
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			segments = append(segments, mdSegment{text: buf.String(), style: style})
+			buf.Reset()
+		}
+	}
+	nested := func(inner string, ns mdStyle) {
+		flush()
+		segments = append(segments, parseInlineMarkdown(inner, ns)...)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			fence := s[i : i+n]
+			if end := strings.Index(s[i+n:], fence); end >= 0 {
+				code := s[i+n : i+n+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				flush()
+				cs := style
+				cs.code = true
+				segments = append(segments, mdSegment{text: code, style: cs})
+				i += n + end + n
+				continue
+			}
+			buf.WriteString(fence)
+			i += n
+			continue
+
+		case strings.HasPrefix(s[i:], "**") || strings.HasPrefix(s[i:], "__"):
+			d := s[i : i+2]
+			if end := findInlineClosing(s, i+2, d); end > i+2 {
+				ns := style
+				ns.bold = true
+				nested(s[i+2:end], ns)
+				i = end + 2
+				continue
+			}
+			buf.WriteString(d)
+			i += 2
+			continue
+
+		case strings.HasPrefix(s[i:], "~~"):
+			if end := findInlineClosing(s, i+2, "~~"); end > i+2 {
+				ns := style
+				ns.strikethrough = true
+				nested(s[i+2:end], ns)
+				i = end + 2
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordByte(s[i-1]))):
+			end := findInlineClosing(s, i+1, string(c))
+			if end > i+1 && (c == '*' || end+1 == len(s) || !isWordByte(s[end+1])) {
+				ns := style
+				ns.italic = true
+				nested(s[i+1:end], ns)
+				i = end + 1
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "<u>"):
+			if end := findInlineClosing(s, i+3, "</u>"); end >= 0 {
+				ns := style
+				ns.underline = true
+				nested(s[i+3:end], ns)
+				i = end + 4
+				continue
+			}
+
+		case c == '[':
+			if text := findInlineClosing(s, i+1, "]"); text >= 0 && strings.HasPrefix(s[text+1:], "(") {
+				if end := strings.IndexByte(s[text+2:], ')'); end >= 0 {
+					ns := style
+					ns.link = strings.TrimSpace(s[text+2 : text+2+end])
+					nested(s[i+1:text], ns)
+					i = text + 2 + end + 1
+					continue
+				}
+			}
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return segments
+}
+
+// findInlineClosing returns the index of the first unescaped delim in s at
+// or after from, or -1. A single character delimiter does not match a
+// doubled one, so that *a **b** c* closes at the last star. Code spans are
+// skipped.
+func findInlineClosing(s string, from int, delim string) int {
+	for i := from; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			i++
+		case s[i] == '`' && delim != "`":
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case strings.HasPrefix(s[i:], delim):
+			if len(delim) == 1 && i+1 < len(s) && s[i+1] == delim[0] {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= utf8.RuneSelf
+}
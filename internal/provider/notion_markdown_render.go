@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// mdLineStart matches the start of a line of text that Markdown would read
+// as the start of a block.
+var mdLineStart = regexp.MustCompile(`^(\s*)(#|>|[-+]\s|[-+]$|\d{1,9}[.)](\s|$)|<(?:details|aside|columns)>)`)
+
+// blocksToMarkdown renders Notion blocks as Markdown. It is the inverse of
+// markdownToBlocks for the blocks markdownToBlocks creates.
+func blocksToMarkdown(blocks []notionapi.Block) string {
+	var sb strings.Builder
+	number := 0
+	var prev notionapi.Block
+	for _, b := range blocks {
+		if b.GetType() == notionapi.BlockTypeNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+		md := blockToMarkdown(b, number)
+		if md == "" {
+			continue
+		}
+		if prev != nil {
+			if isListBlock(prev) && isListBlock(b) {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(md)
+		prev = b
+	}
+	return sb.String()
+}
+
+func isListBlock(b notionapi.Block) bool {
+	switch b.GetType() {
+	case notionapi.BlockTypeBulletedListItem, notionapi.BlockTypeNumberedListItem, notionapi.BlockTypeToDo:
+		return true
+	}
+	return false
+}
+
+// blockToMarkdown renders a single block and its children. number is the
+// position of a numbered list item in its list. Blocks without a Markdown
+// equivalent are rendered as HTML or links where that makes sense, and
+// blocks without content, like a table of contents, as nothing.
+func blockToMarkdown(b notionapi.Block, number int) string {
+	switch b := b.(type) {
+	case *notionapi.ParagraphBlock:
+		return withChildren(textToMarkdown(b.Paragraph.RichText), "  ", b.Paragraph.Children)
+	case *notionapi.Heading1Block:
+		return withChildren("# "+headingToMarkdown(b.Heading1.RichText), "", b.Heading1.Children)
+	case *notionapi.Heading2Block:
+		return withChildren("## "+headingToMarkdown(b.Heading2.RichText), "", b.Heading2.Children)
+	case *notionapi.Heading3Block:
+		return withChildren("### "+headingToMarkdown(b.Heading3.RichText), "", b.Heading3.Children)
+	case *notionapi.BulletedListItemBlock:
+		return listItemToMarkdown("- ", b.BulletedListItem.RichText, b.BulletedListItem.Children)
+	case *notionapi.NumberedListItemBlock:
+		return listItemToMarkdown(strconv.Itoa(max(number, 1))+". ", b.NumberedListItem.RichText, b.NumberedListItem.Children)
+	case *notionapi.ToDoBlock:
+		marker := "- [ ] "
+		if b.ToDo.Checked {
+			marker = "- [x] "
+		}
+		return listItemToMarkdown(marker, b.ToDo.RichText, b.ToDo.Children)
+	case *notionapi.QuoteBlock:
+		return quoteToMarkdown(textToMarkdown(b.Quote.RichText), b.Quote.Children)
+	case *notionapi.CalloutBlock:
+		text := textToMarkdown(b.Callout.RichText)
+		if b.Callout.Icon != nil && b.Callout.Icon.Emoji != nil {
+			text = string(*b.Callout.Icon.Emoji) + " " + text
+		}
+		return containerToMarkdown("<aside>", text, b.Callout.Children, "</aside>")
+	case *notionapi.ToggleBlock:
+		return containerToMarkdown("<details>\n<summary>"+headingToMarkdown(b.Toggle.RichText)+"</summary>", "", b.Toggle.Children, "</details>")
+	case *notionapi.CodeBlock:
+		return codeToMarkdown(richTextPlainText(b.Code.RichText), b.Code.Language)
+	case *notionapi.DividerBlock:
+		return "---"
+	case *notionapi.EquationBlock:
+		return "$$\n" + b.Equation.Expression + "\n$$"
+	case *notionapi.TableBlock:
+		return tableToMarkdown(b.Table.Children)
+	case *notionapi.ColumnListBlock:
+		columns := make([]string, 0, len(b.ColumnList.Children))
+		for _, c := range b.ColumnList.Children {
+			if c, ok := c.(*notionapi.ColumnBlock); ok {
+				columns = append(columns, containerToMarkdown("<column>", "", c.Column.Children, "</column>"))
+			}
+		}
+		return "<columns>\n" + strings.Join(columns, "\n") + "\n</columns>"
+	case *notionapi.ColumnBlock:
+		return blocksToMarkdown(b.Column.Children)
+	case *notionapi.SyncedBlock:
+		return blocksToMarkdown(b.SyncedBlock.Children)
+	case *notionapi.ChildPageBlock:
+		return linkToMarkdown(b.ChildPage.Title, notionURL(b.ID.String()))
+	case *notionapi.ChildDatabaseBlock:
+		return linkToMarkdown(b.ChildDatabase.Title, notionURL(b.ID.String()))
+	case *notionapi.LinkToPageBlock:
+		if b.LinkToPage.DatabaseID != "" {
+			return linkToMarkdown("", notionURL(b.LinkToPage.DatabaseID.String()))
+		}
+		return linkToMarkdown("", notionURL(b.LinkToPage.PageID.String()))
+	case *notionapi.BookmarkBlock:
+		return linkToMarkdown(richTextPlainText(b.Bookmark.Caption), b.Bookmark.URL)
+	case *notionapi.EmbedBlock:
+		return linkToMarkdown(richTextPlainText(b.Embed.Caption), b.Embed.URL)
+	case *notionapi.ImageBlock:
+		return "!" + linkToMarkdown(richTextPlainText(b.Image.Caption), b.Image.GetURL())
+	case *notionapi.VideoBlock:
+		return linkToMarkdown(richTextPlainText(b.Video.Caption), fileURL(b.Video.File, b.Video.External))
+	case *notionapi.AudioBlock:
+		return linkToMarkdown(richTextPlainText(b.Audio.Caption), b.Audio.GetURL())
+	case *notionapi.FileBlock:
+		return linkToMarkdown(richTextPlainText(b.File.Caption), fileURL(b.File.File, b.File.External))
+	case *notionapi.PdfBlock:
+		return linkToMarkdown(richTextPlainText(b.Pdf.Caption), fileURL(b.Pdf.File, b.Pdf.External))
+	case *notionapi.TableOfContentsBlock, *notionapi.BreadcrumbBlock:
+		return ""
+	default:
+		return fmt.Sprintf("<!-- unsupported block: %s -->", b.GetType())
+	}
+}
+
+// quoteToMarkdown renders quoted text and the children of the quote.
+func quoteToMarkdown(text string, children []notionapi.Block) string {
+	if len(children) > 0 {
+		text += "\n\n" + blocksToMarkdown(children)
+	}
+	return prefixLines(text, "> ", "> ")
+}
+
+// containerToMarkdown renders an HTML element holding Markdown: the text
+// and children of a block between the open and close tags.
+func containerToMarkdown(open, text string, children []notionapi.Block, end string) string {
+	md := open
+	if text != "" {
+		md += "\n\n" + text
+	}
+	if len(children) > 0 {
+		md += "\n\n" + blocksToMarkdown(children)
+	}
+	return md + "\n\n" + end
+}
+
+// tableToMarkdown renders table rows as a GitHub flavored Markdown table.
+// The first row is the header of the table, as the format requires one.
+func tableToMarkdown(rows []notionapi.Block) string {
+	var lines []string
+	for i, r := range rows {
+		row, ok := r.(*notionapi.TableRowBlock)
+		if !ok {
+			continue
+		}
+		cells := make([]string, len(row.TableRow.Cells))
+		for j, c := range row.TableRow.Cells {
+			cells[j] = strings.ReplaceAll(headingToMarkdown(c), "|", `\|`)
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// linkToMarkdown renders a link to url, with the url as text if text is
+// empty.
+func linkToMarkdown(text, url string) string {
+	if text == "" {
+		text = url
+	}
+	return "[" + escapeMarkdown(text) + "](" + escapeURL(url) + ")"
+}
+
+func fileURL(file, external *notionapi.FileObject) string {
+	if file != nil {
+		return file.URL
+	}
+	if external != nil {
+		return external.URL
+	}
+	return ""
+}
+
+// notionURL returns the URL of a Notion page or database.
+func notionURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
+
+func headingToMarkdown(rt []notionapi.RichText) string {
+	return strings.ReplaceAll(richTextToMarkdown(rt), "\n", " ")
+}
+
+// textToMarkdown renders the rich text of a paragraph-like block, escaping
+// lines that would otherwise start a different block.
+func textToMarkdown(rt []notionapi.RichText) string {
+	lines := strings.Split(richTextToMarkdown(rt), "\n")
+	for i, l := range lines {
+		lines[i] = escapeLineStart(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeLineStart(line string) string {
+	m := mdLineStart.FindStringSubmatchIndex(line)
+	if m == nil {
+		if mdDivider.MatchString(line) {
+			return `\` + line
+		}
+		return line
+	}
+	start := m[4]
+	if c := line[start]; c >= '0' && c <= '9' {
+		// Escape the period or parenthesis after the number.
+		for start < len(line) && line[start] >= '0' && line[start] <= '9' {
+			start++
+		}
+	}
+	return line[:start] + `\` + line[start:]
+}
+
+func listItemToMarkdown(marker string, rt []notionapi.RichText, children []notionapi.Block) string {
+	indent := strings.Repeat(" ", len(marker))
+	if strings.HasPrefix(marker, "- [") {
+		indent = "  "
+	}
+	text := prefixLines(textToMarkdown(rt), marker, indent)
+	return withChildren(text, indent, children)
+}
+
+// withChildren appends the Markdown of child blocks, indented, to the
+// Markdown of their parent.
+func withChildren(md, indent string, children []notionapi.Block) string {
+	if len(children) == 0 {
+		return md
+	}
+	// A blank line keeps a first child that is not a list item from being
+	// read as more text of the parent.
+	sep := "\n\n"
+	if isListBlock(children[0]) {
+		sep = "\n"
+	}
+	return md + sep + prefixLines(blocksToMarkdown(children), indent, indent)
+}
+
+// prefixLines prefixes the first line of s with first and the others with
+// rest.
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		p := rest
+		if i == 0 {
+			p = first
+		}
+		lines[i] = strings.TrimRight(p+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func codeToMarkdown(code, language string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if language == "plain text" {
+		language = ""
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// richTextToMarkdown renders rich text as inline Markdown.
+func richTextToMarkdown(rt []notionapi.RichText) string {
+	var sb strings.Builder
+	for i := 0; i < len(rt); i++ {
+		style, text := richTextStyle(rt[i])
+		for i+1 < len(rt) {
+			ns, nt := richTextStyle(rt[i+1])
+			if ns != style {
+				break
+			}
+			text += nt
+			i++
+		}
+		sb.WriteString(styledMarkdown(text, style))
+	}
+	return sb.String()
+}
+
+func richTextStyle(r notionapi.RichText) (mdStyle, string) {
+	var style mdStyle
+	if a := r.Annotations; a != nil {
+		style = mdStyle{
+			bold:          a.Bold,
+			italic:        a.Italic,
+			strikethrough: a.Strikethrough,
+			underline:     a.Underline,
+			code:          a.Code,
+		}
+	}
+	text := r.PlainText
+	if r.Text != nil {
+		text = r.Text.Content
+		if r.Text.Link != nil {
+			style.link = r.Text.Link.Url
+		}
+	} else {
+		style.link = r.Href
+	}
+	return style, text
+}
+
+// styledMarkdown renders a run of text with the given style. Surrounding
+// whitespace is kept outside of the delimiters, where Markdown expects it.
+func styledMarkdown(text string, style mdStyle) string {
+	if style.code {
+		text = codeSpan(text)
+	} else {
+		text = escapeMarkdown(text)
+	}
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+
+	if style.strikethrough {
+		core = "~~" + core + "~~"
+	}
+	if style.italic {
+		core = "_" + core + "_"
+	}
+	if style.bold {
+		core = "**" + core + "**"
+	}
+	if style.underline {
+		core = "<u>" + core + "</u>"
+	}
+	if style.link != "" {
+		core = "[" + core + "](" + escapeURL(style.link) + ")"
+	}
+	return lead + core + trail
+}
+
+// codeSpan renders inline code. The delimiters are padded with a space, which
+// the parser strips, if the code contains backticks or is itself padded.
+func codeSpan(code string) string {
+	padded := len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != ""
+	if !strings.Contains(code, "`") && !padded {
+		return "`" + code + "`"
+	}
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + " " + code + " " + fence
+}
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `~`, `\~`, `[`, `\[`, `]`, `\]`, `<`, `\<`,
+)
+
+func escapeMarkdown(text string) string {
+	return mdEscaper.Replace(text)
+}
+
+var urlEscaper = strings.NewReplacer(" ", "%20", ")", "%29")
+
+func escapeURL(url string) string {
+	return urlEscaper.Replace(url)
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jomei/notionapi"
+)
+
+func TestMarkdownToBlocks(t *testing.T) {
+	blocks := markdownToBlocks("# Runbook\n\nFirst line\nsecond line\n\n- one\n  - nested\n- [x] done\n1. first\n\n> quote\n\n```go\nfmt.Println()\n```\n\n---")
+
+	var types []notionapi.BlockType
+	for _, b := range blocks {
+		types = append(types, b.GetType())
+	}
+	want := []notionapi.BlockType{
+		notionapi.BlockTypeHeading1,
+		notionapi.BlockTypeParagraph,
+		notionapi.BlockTypeBulletedListItem,
+		notionapi.BlockTypeToDo,
+		notionapi.BlockTypeNumberedListItem,
+		notionapi.BlockQuote,
+		notionapi.BlockTypeCode,
+		notionapi.BlockTypeDivider,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("block types = %v, want %v", types, want)
+	}
+
+	if got := richTextPlainText(blocks[1].(*notionapi.ParagraphBlock).Paragraph.RichText); got != "First line\nsecond line" {
+		t.Errorf("paragraph = %q", got)
+	}
+	item := blocks[2].(*notionapi.BulletedListItemBlock).BulletedListItem
+	if len(item.Children) != 1 || item.Children[0].GetType() != notionapi.BlockTypeBulletedListItem {
+		t.Errorf("list item children = %v, want one nested item", item.Children)
+	}
+	if !blocks[3].(*notionapi.ToDoBlock).ToDo.Checked {
+		t.Error("to-do is not checked")
+	}
+	if got := blocks[6].(*notionapi.CodeBlock).Code.Language; got != "go" {
+		t.Errorf("code language = %q, want go", got)
+	}
+}
+
+func TestMarkdownRichText(t *testing.T) {
+	rt := markdownRichText("Plain **bold _both_** `co*de` [link](https://example.com) ~~gone~~ <u>under</u> snake_case \\*lit\\*")
+
+	type segment struct {
+		text string
+		ann  notionapi.Annotations
+		link string
+	}
+	var got []segment
+	for _, r := range rt {
+		s := segment{text: r.Text.Content, ann: *r.Annotations}
+		s.ann.Color = ""
+		if r.Text.Link != nil {
+			s.link = r.Text.Link.Url
+		}
+		got = append(got, s)
+	}
+	want := []segment{
+		{text: "Plain "},
+		{text: "bold ", ann: notionapi.Annotations{Bold: true}},
+		{text: "both", ann: notionapi.Annotations{Bold: true, Italic: true}},
+		{text: " "},
+		{text: "co*de", ann: notionapi.Annotations{Code: true}},
+		{text: " "},
+		{text: "link", link: "https://example.com"},
+		{text: " "},
+		{text: "gone", ann: notionapi.Annotations{Strikethrough: true}},
+		{text: " "},
+		{text: "under", ann: notionapi.Annotations{Underline: true}},
+		{text: " snake_case *lit*"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("markdownRichText() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	for _, md := range []string{
+		"# Title\n\n## Section\n\n### Details",
+		"Some **bold**, _italic_, ~~struck~~, <u>underlined</u> and `code` text with a [link](https://example.com/a%20b).",
+		"Escaped \\*stars\\*, snake\\_case and 1. mid-line\n1\\. not a list\n\\# not a heading",
+		"- one\n- two\n  - nested\n\n    nested paragraph\n- [ ] todo\n- [x] done\n1. first\n2. second",
+		"> quoted\n> lines",
+		"```python\nprint(\"hi\")\n\n# comment\n```\n\n````\n```\n````",
+		"Before\n\n---\n\nAfter",
+		"<details>\n<summary>On **call**</summary>\n\n- page\n- ack\n\n</details>",
+		"<aside>\n\n🚨 Read first\n\nDetails\n\n</aside>",
+		"<columns>\n<column>\n\n# Left\n\n</column>\n<column>\n\n<details>\n<summary>Right</summary>\n\n</details>\n\n</column>\n</columns>",
+		"\\<aside> is text",
+	} {
+		if got := blocksToMarkdown(markdownToBlocks(md)); got != md {
+			t.Errorf("round trip of\n%s\ngot\n%s", md, got)
+		}
+	}
+}
+
+func TestSplitRichText(t *testing.T) {
+	long := make([]rune, notionRichTextLimit+1)
+	for i := range long {
+		long[i] = 'é'
+	}
+	rt := splitRichText(string(long), nil)
+	if len(rt) != 2 || len([]rune(rt[0].Text.Content)) != notionRichTextLimit || rt[1].Text.Content != "é" {
+		t.Errorf("splitRichText() returned %d objects", len(rt))
+	}
+}
+
+func TestBlocksToMarkdown(t *testing.T) {
+	emoji := notionapi.Emoji("💡")
+	row := func(cells ...string) notionapi.Block {
+		r := &notionapi.TableRowBlock{BasicBlock: newBasicBlock(notionapi.BlockTypeTableRowBlock)}
+		for _, c := range cells {
+			r.TableRow.Cells = append(r.TableRow.Cells, plainRichText(c))
+		}
+		return r
+	}
+	childPage := &notionapi.ChildPageBlock{BasicBlock: notionapi.BasicBlock{
+		Type: notionapi.BlockTypeChildPage,
+		ID:   "8263e830-3424-475a-801c-1d971606cd6c",
+	}}
+	childPage.ChildPage.Title = "Escalation"
+
+	blocks := []notionapi.Block{
+		&notionapi.TableOfContentsBlock{BasicBlock: newBasicBlock(notionapi.BlockTypeTableOfContents)},
+		&notionapi.CalloutBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockCallout),
+			Callout:    notionapi.Callout{RichText: plainRichText("Read first"), Icon: &notionapi.Icon{Emoji: &emoji}},
+		},
+		&notionapi.ToggleBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeToggle),
+			Toggle:     notionapi.Toggle{RichText: plainRichText("Details"), Children: []notionapi.Block{paragraphBlock("Hidden")}},
+		},
+		&notionapi.TableBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeTableBlock),
+			Table:      notionapi.Table{TableWidth: 2, Children: []notionapi.Block{row("Service", "Owner"), row("api", "a|b")}},
+		},
+		&notionapi.ImageBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeImage),
+			Image:      notionapi.Image{External: &notionapi.FileObject{URL: "https://example.com/a.png"}},
+		},
+		childPage,
+	}
+
+	want := "<aside>\n\n💡 Read first\n\n</aside>\n\n" +
+		"<details>\n<summary>Details</summary>\n\nHidden\n\n</details>\n\n" +
+		"| Service | Owner |\n| --- | --- |\n| api | a\\|b |\n\n" +
+		"![https://example.com/a.png](https://example.com/a.png)\n\n" +
+		"[Escalation](https://www.notion.so/8263e8303424475a801c1d971606cd6c)"
+	if got := blocksToMarkdown(blocks); got != want {
+		t.Errorf("blocksToMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdownContainers(t *testing.T) {
+	blocks := markdownToBlocks("<aside>\nNo icon\n\n- item\n</aside>\n\n<columns>\n<column>\n</column>\n<column>\nRight\n</column>\n</columns>")
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+
+	callout := blocks[0].(*notionapi.CalloutBlock).Callout
+	if got := richTextPlainText(callout.RichText); got != "No icon" {
+		t.Errorf("callout text = %q", got)
+	}
+	if callout.Icon == nil || string(*callout.Icon.Emoji) != notionDefaultCalloutIcon {
+		t.Errorf("callout icon = %v, want the default icon", callout.Icon)
+	}
+	if len(callout.Children) != 1 || callout.Children[0].GetType() != notionapi.BlockTypeBulletedListItem {
+		t.Errorf("callout children = %v", callout.Children)
+	}
+
+	columns := blocks[1].(*notionapi.ColumnListBlock).ColumnList.Children
+	if len(columns) != 2 {
+		t.Fatalf("got %d columns, want 2", len(columns))
+	}
+	if got := columns[0].(*notionapi.ColumnBlock).Column.Children; len(got) != 1 || got[0].GetType() != notionapi.BlockTypeParagraph {
+		t.Errorf("empty column children = %v, want an empty paragraph", got)
+	}
+}
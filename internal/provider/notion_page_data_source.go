@@ -16,10 +16,12 @@ var (
 )
 
 type notionPageModel struct {
-	ID          types.String `tfsdk:"id"`
-	URL         types.String `tfsdk:"url"`
-	ParentID    types.String `tfsdk:"parent_id"`
-	CreatedTime types.String `tfsdk:"created_time"`
+	ID              types.String `tfsdk:"id"`
+	URL             types.String `tfsdk:"url"`
+	ParentID        types.String `tfsdk:"parent_id"`
+	ParentType      types.String `tfsdk:"parent_type"`
+	CreatedTime     types.String `tfsdk:"created_time"`
+	ContentMarkdown types.String `tfsdk:"content_markdown"`
 }
 
 func NewNotionPageDataSource() datasource.DataSource {
@@ -27,7 +29,7 @@ func NewNotionPageDataSource() datasource.DataSource {
 }
 
 type notionPageDataSource struct {
-	client *notionapi.Client
+	client *notionClient
 }
 
 func (d *notionPageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -48,13 +50,21 @@ func (d *notionPageDataSource) Schema(ctx context.Context, req datasource.Schema
 				Computed:            true,
 			},
 			"parent_id": schema.StringAttribute{
-				MarkdownDescription: "The page's parent id.",
+				MarkdownDescription: "The page's parent id. Empty for workspace parents.",
+				Computed:            true,
+			},
+			"parent_type": schema.StringAttribute{
+				MarkdownDescription: "The page's parent type, one of `page_id`, `database_id`, `block_id` or `workspace`.",
 				Computed:            true,
 			},
 			"created_time": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when this page was created.",
 				Computed:            true,
 			},
+			"content_markdown": schema.StringAttribute{
+				MarkdownDescription: "The page's content rendered as Markdown. Child pages and databases are rendered as links, and so are media and bookmarks; toggles, callouts and column layouts use HTML `<details>`, `<aside>` and `<columns>` elements.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -75,11 +85,23 @@ func (d *notionPageDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	blocks, err := getBlockChildren(ctx, d.client, notionapi.BlockID(page.ID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get page content",
+			fmt.Sprintf("Failed to get page content: %s", err),
+		)
+		return
+	}
+
+	parentType, parentID := flattenParent(page.Parent)
 	state := &notionPageModel{
-		ID:          types.StringValue(page.ID.String()),
-		URL:         types.StringValue(page.URL),
-		ParentID:    types.StringValue(page.Parent.PageID.String()),
-		CreatedTime: types.StringValue(page.CreatedTime.String()),
+		ID:              types.StringValue(page.ID.String()),
+		URL:             types.StringValue(page.URL),
+		ParentID:        types.StringValue(parentID),
+		ParentType:      types.StringValue(parentType),
+		CreatedTime:     types.StringValue(page.CreatedTime.String()),
+		ContentMarkdown: types.StringValue(blocksToMarkdown(blocks)),
 	}
 
 	diags := resp.State.Set(ctx, state)
@@ -94,11 +116,11 @@ func (d *notionPageDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*notionapi.Client)
+	client, ok := req.ProviderData.(*notionClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *notionapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -0,0 +1,517 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionPagePropertyValueModel is the value of a database row property.
+// Exactly one of its attributes is set, the one of the property's type.
+type notionPagePropertyValueModel struct {
+	Title       types.String           `tfsdk:"title"`
+	RichText    types.String           `tfsdk:"rich_text"`
+	Number      types.Float64          `tfsdk:"number"`
+	Select      types.String           `tfsdk:"select"`
+	MultiSelect []types.String         `tfsdk:"multi_select"`
+	Status      types.String           `tfsdk:"status"`
+	Date        *notionDateValueModel  `tfsdk:"date"`
+	Checkbox    types.Bool             `tfsdk:"checkbox"`
+	URL         types.String           `tfsdk:"url"`
+	Email       types.String           `tfsdk:"email"`
+	PhoneNumber types.String           `tfsdk:"phone_number"`
+	People      []types.String         `tfsdk:"people"`
+	Relation    []types.String         `tfsdk:"relation"`
+	Files       []notionFileValueModel `tfsdk:"files"`
+}
+
+type notionDateValueModel struct {
+	Start types.String `tfsdk:"start"`
+	End   types.String `tfsdk:"end"`
+}
+
+type notionFileValueModel struct {
+	Name types.String `tfsdk:"name"`
+	URL  types.String `tfsdk:"url"`
+}
+
+// pagePropertyValueAttributes returns the attributes of a property value.
+func pagePropertyValueAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"title": schema.StringAttribute{
+			MarkdownDescription: "The value of a title property, as plain text.",
+			Optional:            true,
+			Validators: []validator.String{
+				plainTextValidator(),
+			},
+		},
+		"rich_text": schema.StringAttribute{
+			MarkdownDescription: "The value of a rich text property, as plain text.",
+			Optional:            true,
+			Validators: []validator.String{
+				plainTextValidator(),
+			},
+		},
+		"number": schema.Float64Attribute{
+			MarkdownDescription: "The value of a number property.",
+			Optional:            true,
+		},
+		"select": schema.StringAttribute{
+			MarkdownDescription: "The name of the option of a select property. Notion adds options the database doesn't have yet.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"multi_select": schema.SetAttribute{
+			MarkdownDescription: "The names of the options of a multi-select property. Notion adds options the database doesn't have yet.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The name of the option of a status property, which must exist in the database.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"date": schema.SingleNestedAttribute{
+			MarkdownDescription: "The value of a date property.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"start": schema.StringAttribute{
+					MarkdownDescription: "The start of the date, as `YYYY-MM-DD` or an RFC 3339 timestamp.",
+					Required:            true,
+					Validators: []validator.String{
+						notionDateValidator{},
+					},
+				},
+				"end": schema.StringAttribute{
+					MarkdownDescription: "The end of a date range, in the same format as `start`.",
+					Optional:            true,
+					Validators: []validator.String{
+						notionDateValidator{},
+					},
+				},
+			},
+		},
+		"checkbox": schema.BoolAttribute{
+			MarkdownDescription: "The value of a checkbox property.",
+			Optional:            true,
+		},
+		"url": schema.StringAttribute{
+			MarkdownDescription: "The value of a URL property.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "The value of an email property.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"phone_number": schema.StringAttribute{
+			MarkdownDescription: "The value of a phone number property.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"people": schema.SetAttribute{
+			MarkdownDescription: "The ids of the users of a people property.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"relation": schema.SetAttribute{
+			MarkdownDescription: "The ids of the pages of a relation property.",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"files": schema.ListNestedAttribute{
+			MarkdownDescription: "The external files of a files property.",
+			Optional:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "The name of the file.",
+						Required:            true,
+					},
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the file.",
+						Required:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// pagePropertyValueType returns the type of the property a value is for, or
+// the empty string if no value is set.
+func pagePropertyValueType(v notionPagePropertyValueModel) notionapi.PropertyType {
+	if ts := pagePropertyValueTypes(v); len(ts) > 0 {
+		return ts[0]
+	}
+	return ""
+}
+
+// pagePropertyValueTypes returns the types of all the values set, of which
+// there must be exactly one.
+func pagePropertyValueTypes(v notionPagePropertyValueModel) []notionapi.PropertyType {
+	var ts []notionapi.PropertyType
+	for _, value := range []struct {
+		typ notionapi.PropertyType
+		set bool
+	}{
+		{notionapi.PropertyTypeTitle, !v.Title.IsNull()},
+		{notionapi.PropertyTypeRichText, !v.RichText.IsNull()},
+		{notionapi.PropertyTypeNumber, !v.Number.IsNull()},
+		{notionapi.PropertyTypeSelect, !v.Select.IsNull()},
+		{notionapi.PropertyTypeMultiSelect, v.MultiSelect != nil},
+		{notionapi.PropertyTypeStatus, !v.Status.IsNull()},
+		{notionapi.PropertyTypeDate, v.Date != nil},
+		{notionapi.PropertyTypeCheckbox, !v.Checkbox.IsNull()},
+		{notionapi.PropertyTypeURL, !v.URL.IsNull()},
+		{notionapi.PropertyTypeEmail, !v.Email.IsNull()},
+		{notionapi.PropertyTypePhoneNumber, !v.PhoneNumber.IsNull()},
+		{notionapi.PropertyTypePeople, v.People != nil},
+		{notionapi.PropertyTypeRelation, v.Relation != nil},
+		{notionapi.PropertyTypeFiles, v.Files != nil},
+	} {
+		if value.set {
+			ts = append(ts, value.typ)
+		}
+	}
+	return ts
+}
+
+// pageTitleValue returns the title set through the properties of a page, if
+// any.
+func pageTitleValue(values map[string]notionPagePropertyValueModel) (types.String, bool) {
+	for _, v := range values {
+		if !v.Title.IsNull() {
+			return v.Title, true
+		}
+	}
+	return types.StringNull(), false
+}
+
+// expandPagePropertyValues converts property values into Notion page
+// properties. Only the values that differ from prior are included if prior
+// is not nil.
+func expandPagePropertyValues(values, prior map[string]notionPagePropertyValueModel) notionapi.Properties {
+	props := notionapi.Properties{}
+	for name, v := range values {
+		if old, ok := prior[name]; ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		props[name] = expandPagePropertyValue(v)
+	}
+	return props
+}
+
+func expandPagePropertyValue(v notionPagePropertyValueModel) notionapi.Property {
+	switch t := pagePropertyValueType(v); t {
+	case notionapi.PropertyTypeTitle:
+		return notionapi.TitleProperty{Type: t, Title: plainRichText(v.Title.ValueString())}
+	case notionapi.PropertyTypeRichText:
+		return notionapi.RichTextProperty{Type: t, RichText: plainRichText(v.RichText.ValueString())}
+	case notionapi.PropertyTypeNumber:
+		return notionapi.NumberProperty{Type: t, Number: v.Number.ValueFloat64()}
+	case notionapi.PropertyTypeSelect:
+		return notionapi.SelectProperty{Type: t, Select: notionapi.Option{Name: v.Select.ValueString()}}
+	case notionapi.PropertyTypeMultiSelect:
+		options := make([]notionapi.Option, 0, len(v.MultiSelect))
+		for _, o := range v.MultiSelect {
+			options = append(options, notionapi.Option{Name: o.ValueString()})
+		}
+		return notionapi.MultiSelectProperty{Type: t, MultiSelect: options}
+	case notionapi.PropertyTypeStatus:
+		return notionapi.StatusProperty{Type: t, Status: notionapi.Status{Name: v.Status.ValueString()}}
+	case notionapi.PropertyTypeDate:
+		date := notionDateValue{Start: v.Date.Start.ValueString()}
+		if !v.Date.End.IsNull() {
+			end := v.Date.End.ValueString()
+			date.End = &end
+		}
+		return notionDatePropertyValue{Type: t, Date: date}
+	case notionapi.PropertyTypeCheckbox:
+		return notionapi.CheckboxProperty{Type: t, Checkbox: v.Checkbox.ValueBool()}
+	case notionapi.PropertyTypeURL:
+		return notionapi.URLProperty{Type: t, URL: v.URL.ValueString()}
+	case notionapi.PropertyTypeEmail:
+		return notionapi.EmailProperty{Type: t, Email: v.Email.ValueString()}
+	case notionapi.PropertyTypePhoneNumber:
+		return notionapi.PhoneNumberProperty{Type: t, PhoneNumber: v.PhoneNumber.ValueString()}
+	case notionapi.PropertyTypePeople:
+		people := make([]notionapi.User, 0, len(v.People))
+		for _, id := range v.People {
+			people = append(people, notionapi.User{Object: notionapi.ObjectTypeUser, ID: notionapi.UserID(id.ValueString())})
+		}
+		return notionapi.PeopleProperty{Type: t, People: people}
+	case notionapi.PropertyTypeRelation:
+		relations := make([]notionapi.Relation, 0, len(v.Relation))
+		for _, id := range v.Relation {
+			relations = append(relations, notionapi.Relation{ID: notionapi.PageID(id.ValueString())})
+		}
+		return notionapi.RelationProperty{Type: t, Relation: relations}
+	case notionapi.PropertyTypeFiles:
+		files := make([]notionapi.File, 0, len(v.Files))
+		for _, f := range v.Files {
+			files = append(files, notionapi.File{
+				Name:     f.Name.ValueString(),
+				Type:     notionapi.FileTypeExternal,
+				External: &notionapi.FileObject{URL: f.URL.ValueString()},
+			})
+		}
+		return notionapi.FilesProperty{Type: t, Files: files}
+	}
+	return nil
+}
+
+// notionDatePropertyValue is a date property value. notionapi.DateProperty
+// always sends timestamps, which turns dates into date times.
+type notionDatePropertyValue struct {
+	Type notionapi.PropertyType `json:"type"`
+	Date notionDateValue        `json:"date"`
+}
+
+type notionDateValue struct {
+	Start string  `json:"start"`
+	End   *string `json:"end"`
+}
+
+func (p notionDatePropertyValue) GetID() string {
+	return ""
+}
+
+func (p notionDatePropertyValue) GetType() notionapi.PropertyType {
+	return p.Type
+}
+
+// flattenPagePropertyValue converts a Notion page property into a property
+// value. Equivalent prior values, such as ids written without dashes, are
+// kept as configured.
+func flattenPagePropertyValue(p notionapi.Property, prior notionPagePropertyValueModel) notionPagePropertyValueModel {
+	v := newPagePropertyValue()
+	switch p := p.(type) {
+	case *notionapi.TitleProperty:
+		v.Title = types.StringValue(richTextPlainText(p.Title))
+	case *notionapi.RichTextProperty:
+		v.RichText = types.StringValue(richTextPlainText(p.RichText))
+	case *notionapi.NumberProperty:
+		v.Number = types.Float64Value(p.Number)
+	case *notionapi.SelectProperty:
+		if p.Select.Name != "" {
+			v.Select = types.StringValue(p.Select.Name)
+		}
+	case *notionapi.MultiSelectProperty:
+		v.MultiSelect = []types.String{}
+		for _, o := range p.MultiSelect {
+			v.MultiSelect = append(v.MultiSelect, types.StringValue(o.Name))
+		}
+	case *notionapi.StatusProperty:
+		if p.Status.Name != "" {
+			v.Status = types.StringValue(p.Status.Name)
+		}
+	case *notionapi.DateProperty:
+		if p.Date != nil && p.Date.Start != nil {
+			v.Date = &notionDateValueModel{End: types.StringNull()}
+			var start, end types.String
+			if prior.Date != nil {
+				start, end = prior.Date.Start, prior.Date.End
+			}
+			v.Date.Start = flattenDate(p.Date.Start, start)
+			if p.Date.End != nil {
+				v.Date.End = flattenDate(p.Date.End, end)
+			}
+		}
+	case *notionapi.CheckboxProperty:
+		v.Checkbox = types.BoolValue(p.Checkbox)
+	case *notionapi.URLProperty:
+		if p.URL != "" {
+			v.URL = types.StringValue(p.URL)
+		}
+	case *notionapi.EmailProperty:
+		if p.Email != "" {
+			v.Email = types.StringValue(p.Email)
+		}
+	case *notionapi.PhoneNumberProperty:
+		if p.PhoneNumber != "" {
+			v.PhoneNumber = types.StringValue(p.PhoneNumber)
+		}
+	case *notionapi.PeopleProperty:
+		v.People = []types.String{}
+		for _, u := range p.People {
+			v.People = append(v.People, flattenID(u.ID.String(), prior.People))
+		}
+	case *notionapi.RelationProperty:
+		v.Relation = []types.String{}
+		for _, r := range p.Relation {
+			v.Relation = append(v.Relation, flattenID(r.ID.String(), prior.Relation))
+		}
+	case *notionapi.FilesProperty:
+		v.Files = []notionFileValueModel{}
+		for _, f := range p.Files {
+			url := ""
+			switch {
+			case f.External != nil:
+				url = f.External.URL
+			case f.File != nil:
+				url = f.File.URL
+			}
+			v.Files = append(v.Files, notionFileValueModel{
+				Name: types.StringValue(f.Name),
+				URL:  types.StringValue(url),
+			})
+		}
+	}
+	return v
+}
+
+// newPagePropertyValue returns a property value with no value set.
+func newPagePropertyValue() notionPagePropertyValueModel {
+	return notionPagePropertyValueModel{
+		Title:       types.StringNull(),
+		RichText:    types.StringNull(),
+		Number:      types.Float64Null(),
+		Select:      types.StringNull(),
+		Status:      types.StringNull(),
+		Checkbox:    types.BoolNull(),
+		URL:         types.StringNull(),
+		Email:       types.StringNull(),
+		PhoneNumber: types.StringNull(),
+	}
+}
+
+// flattenID returns id, or the equal id from prior as it was written.
+func flattenID(id string, prior []types.String) types.String {
+	for _, p := range prior {
+		if notionIDEqual(p.ValueString(), id) {
+			return p
+		}
+	}
+	return types.StringValue(id)
+}
+
+// flattenDate returns a date as YYYY-MM-DD if it has no time, or as an RFC
+// 3339 timestamp. prior is kept if it is the same point in time.
+func flattenDate(d *notionapi.Date, prior types.String) types.String {
+	t := time.Time(*d)
+	if p, err := parseNotionDate(prior.ValueString()); err == nil && p.Equal(t) {
+		return prior
+	}
+	if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)) {
+		return types.StringValue(t.Format(time.DateOnly))
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
+
+func parseNotionDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// validatePagePropertyValueCounts checks that every property value, at
+// attribute p, sets exactly one value.
+func validatePagePropertyValueCounts(values map[string]notionPagePropertyValueModel, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name, v := range values {
+		if ts := pagePropertyValueTypes(v); len(ts) != 1 {
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Invalid property value",
+				fmt.Sprintf("Property %q must set exactly one value, got %d.", name, len(ts)),
+			)
+		}
+	}
+	return diags
+}
+
+// validatePagePropertyValues checks property values, at attribute p, against
+// the schema of the database they are set in.
+func validatePagePropertyValues(values map[string]notionPagePropertyValueModel, p path.Path, db *notionDatabase) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := values[name]
+		config, ok := db.Properties[name]
+		if !ok {
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Unknown property",
+				fmt.Sprintf("The parent database has no property %q.", name),
+			)
+			continue
+		}
+		t := pagePropertyValueType(v)
+		if string(t) != string(config.GetType()) {
+			diags.AddAttributeError(
+				p.AtMapKey(name),
+				"Mismatched property type",
+				fmt.Sprintf("Property %q is of type %q but a %s value is set.", name, config.GetType(), t),
+			)
+			continue
+		}
+		if status, ok := config.(*notionapi.StatusPropertyConfig); ok && !v.Status.IsUnknown() {
+			found := false
+			for _, o := range status.Status.Options {
+				found = found || o.Name == v.Status.ValueString()
+			}
+			if !found {
+				diags.AddAttributeError(
+					p.AtMapKey(name).AtName("status"),
+					"Unknown status",
+					fmt.Sprintf("Property %q has no status option %q.", name, v.Status.ValueString()),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// notionDateValidator validates that a string is a date or an RFC 3339
+// timestamp.
+type notionDateValidator struct{}
+
+func (v notionDateValidator) Description(_ context.Context) string {
+	return "value must be a date formatted as YYYY-MM-DD or an RFC 3339 timestamp"
+}
+
+func (v notionDateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v notionDateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseNotionDate(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid date",
+			fmt.Sprintf("Expected a date formatted as YYYY-MM-DD or an RFC 3339 timestamp, got %q.", req.ConfigValue.ValueString()),
+		)
+	}
+}
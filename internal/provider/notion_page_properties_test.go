@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+func TestExpandPagePropertyValues(t *testing.T) {
+	values := map[string]notionPagePropertyValueModel{
+		"Due":  {Date: &notionDateValueModel{Start: types.StringValue("2024-03-01"), End: types.StringNull()}},
+		"Tags": {MultiSelect: []types.String{types.StringValue("ops")}},
+		"Done": {Checkbox: types.BoolValue(true)},
+	}
+	prior := map[string]notionPagePropertyValueModel{
+		"Done": {Checkbox: types.BoolValue(true)},
+	}
+
+	got, err := json.Marshal(expandPagePropertyValues(values, prior))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Due":{"type":"date","date":{"start":"2024-03-01","end":null}},"Tags":{"type":"multi_select","multi_select":[{"name":"ops"}]}}`
+	if string(got) != want {
+		t.Errorf("expandPagePropertyValues() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExpandPagePropertyValueLongText(t *testing.T) {
+	text := strings.Repeat("é", 4500)
+	p, ok := expandPagePropertyValue(notionPagePropertyValueModel{RichText: types.StringValue(text)}).(notionapi.RichTextProperty)
+	if !ok {
+		t.Fatalf("expandPagePropertyValue() returned %T, want a rich text property", p)
+	}
+	var lengths []int
+	for _, rt := range p.RichText {
+		lengths = append(lengths, len([]rune(rt.Text.Content)))
+	}
+	if len(lengths) != 3 || lengths[0] != 2000 || lengths[1] != 2000 || lengths[2] != 500 {
+		t.Errorf("rich text objects of %v characters, want [2000 2000 500]", lengths)
+	}
+	if got := richTextPlainText(p.RichText); got != text {
+		t.Error("rich text objects don't add up to the value")
+	}
+
+	for _, tt := range []struct {
+		text    string
+		wantErr bool
+	}{
+		{text: text},
+		{text: strings.Repeat("a", notionMaxRichTextLength*notionMaxRichTextObjects+1), wantErr: true},
+	} {
+		resp := &validator.StringResponse{}
+		plainTextValidator().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("rich_text"),
+			ConfigValue: types.StringValue(tt.text),
+		}, resp)
+		if resp.Diagnostics.HasError() != tt.wantErr {
+			t.Errorf("plainTextValidator() of %d characters: errors %v, want %t", len(tt.text), resp.Diagnostics, tt.wantErr)
+		}
+	}
+}
+
+func TestFlattenPagePropertyValue(t *testing.T) {
+	relation := &notionapi.RelationProperty{
+		Type:     notionapi.PropertyTypeRelation,
+		Relation: []notionapi.Relation{{ID: "8263e830-3424-475a-801c-1d971606cd6c"}, {ID: "11111111-2222-3333-4444-555555555555"}},
+	}
+	prior := notionPagePropertyValueModel{Relation: []types.String{types.StringValue("8263e8303424475a801c1d971606cd6c")}}
+	got := flattenPagePropertyValue(relation, prior).Relation
+	if len(got) != 2 || got[0].ValueString() != "8263e8303424475a801c1d971606cd6c" || got[1].ValueString() != "11111111-2222-3333-4444-555555555555" {
+		t.Errorf("relation = %v", got)
+	}
+
+	start := notionapi.Date(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC))
+	day := notionapi.Date(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	date := &notionapi.DateProperty{Type: notionapi.PropertyTypeDate, Date: &notionapi.DateObject{Start: &start, End: &day}}
+	prior = notionPagePropertyValueModel{Date: &notionDateValueModel{Start: types.StringValue("2024-03-01T10:30:00+01:00"), End: types.StringNull()}}
+	v := flattenPagePropertyValue(date, prior)
+	if v.Date.Start.ValueString() != "2024-03-01T10:30:00+01:00" || v.Date.End.ValueString() != "2024-03-02" {
+		t.Errorf("date = %+v", v.Date)
+	}
+	if pagePropertyValueType(v) != notionapi.PropertyTypeDate {
+		t.Errorf("pagePropertyValueType() = %q", pagePropertyValueType(v))
+	}
+}
+
+func TestValidatePagePropertyValues(t *testing.T) {
+	var db notionDatabase
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": {}},
+			"Status": {"id": "st", "type": "status", "status": {"options": [{"name": "Done"}], "groups": []}},
+			"Done": {"id": "dn", "type": "checkbox", "checkbox": {}}
+		}
+	}`), &db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := validatePagePropertyValues(map[string]notionPagePropertyValueModel{
+		"Name":    {Title: types.StringValue("Row")},
+		"Status":  {Status: types.StringValue("Blocked")},
+		"Done":    {URL: types.StringValue("https://example.com")},
+		"Missing": {Number: types.Float64Value(1)},
+	}, path.Root("properties"), &db)
+
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary())
+	}
+	want := []string{"Mismatched property type", "Unknown property", "Unknown status"}
+	if len(summaries) != len(want) {
+		t.Fatalf("diagnostics = %v, want %v", summaries, want)
+	}
+	for i := range want {
+		if summaries[i] != want[i] {
+			t.Errorf("diagnostics = %v, want %v", summaries, want)
+		}
+	}
+}
@@ -0,0 +1,554 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jomei/notionapi"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &notionPageResource{}
+	_ resource.ResourceWithConfigure      = &notionPageResource{}
+	_ resource.ResourceWithValidateConfig = &notionPageResource{}
+	_ resource.ResourceWithModifyPlan     = &notionPageResource{}
+	_ resource.ResourceWithImportState    = &notionPageResource{}
+)
+
+// notionPageTitleProperty is the id of the title property of every page,
+// whether its parent is a page or a database.
+const notionPageTitleProperty = "title"
+
+type notionPageResourceModel struct {
+	ID                types.String                            `tfsdk:"id"`
+	URL               types.String                            `tfsdk:"url"`
+	ParentID          types.String                            `tfsdk:"parent_id"`
+	ParentType        types.String                            `tfsdk:"parent_type"`
+	CurrentParentID   types.String                            `tfsdk:"current_parent_id"`
+	CurrentParentType types.String                            `tfsdk:"current_parent_type"`
+	CreatedTime       types.String                            `tfsdk:"created_time"`
+	Title             types.String                            `tfsdk:"title"`
+	Icon              *notionIconModel                        `tfsdk:"icon"`
+	Cover             *notionCoverModel                       `tfsdk:"cover"`
+	Content           types.String                            `tfsdk:"content_markdown"`
+	BlockIDs          types.List                              `tfsdk:"content_block_ids"`
+	Properties        map[string]notionPagePropertyValueModel `tfsdk:"properties"`
+}
+
+// NewNotionPageResource is a helper function to simplify the provider implementation.
+func NewNotionPageResource() resource.Resource {
+	return &notionPageResource{}
+}
+
+// notionPageResource is the resource implementation.
+type notionPageResource struct {
+	client *notionClient
+}
+
+// Metadata returns the resource type name.
+func (r *notionPageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notion_page"
+}
+
+// Schema defines the schema for the resource.
+func (r *notionPageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Notion page, either a child page of another page or a row of a database. Destroying the resource moves the page to the trash.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Notion page id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Notion page url",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "The page's parent id.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_type": schema.StringAttribute{
+				MarkdownDescription: "The page's parent type, `page_id` (the default) or `database_id`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(notionapi.ParentTypePageID)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(notionapi.ParentTypePageID), string(notionapi.ParentTypeDatabaseID)),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"current_parent_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the page's parent in Notion. It differs from `parent_id` once the page is moved outside of Terraform, which doesn't replace the page.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_parent_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the page's parent in Notion, such as `block_id` once the page is moved into a block.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_time": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when this page was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The page's title as plain text. For database rows, this is the value of the database's title property, which can be set here or in `properties`; exactly one of them must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("properties").AtAnyMapKey().AtName("title")),
+					plainTextValidator(),
+				},
+			},
+			"icon":  iconResourceAttribute("The page's icon."),
+			"cover": coverResourceAttribute("The page's cover image."),
+			"content_markdown": schema.StringAttribute{
+				MarkdownDescription: "The page's content as Markdown. Headings, paragraphs, bulleted, numbered and to-do lists, quotes, fenced code, dividers, links and bold, italic, strikethrough, inline code and `<u>underline</u>` text are supported. " +
+					"Toggles, callouts and column layouts are written as `<details>`, `<aside>` and `<columns>` elements holding Markdown, with a `<column>` element for each column. " +
+					"Changes are applied by replacing the blocks that differ; child pages and databases are left alone. If unset, the content is not managed.",
+				Optional: true,
+			},
+			"content_block_ids": schema.ListAttribute{
+				MarkdownDescription: "The ids of the top-level blocks of `content_markdown`, in order, to place blocks after them or nest blocks in them.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"properties": schema.MapNestedAttribute{
+				MarkdownDescription: "The property values of a database row, keyed by property name. Each value sets exactly one attribute, the one named after the property's type. " +
+					"Values are checked against the database's schema when planning. Properties that are not listed are left alone.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: pagePropertyValueAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates that every property sets one value, and that only
+// database rows set properties other than the title.
+func (r *notionPageResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if unknownModelValues(req.Config.Raw, "icon", "cover", "properties") {
+		// Validated once the values are known.
+		return
+	}
+
+	var config notionPageResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePagePropertyValueCounts(config.Properties, path.Root("properties"))...)
+
+	isRow := config.ParentType.ValueString() == string(notionapi.ParentTypeDatabaseID)
+	for name, v := range config.Properties {
+		ts := pagePropertyValueTypes(v)
+		if len(ts) != 1 {
+			continue
+		}
+		if !isRow && !config.ParentType.IsUnknown() && ts[0] != notionapi.PropertyTypeTitle {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("properties").AtMapKey(name),
+				"Unexpected property value",
+				fmt.Sprintf("Property %q sets a %s value, but pages whose parent is not a database only have a title.", name, ts[0]),
+			)
+		}
+	}
+}
+
+// ModifyPlan derives the title from the properties when it is set there, and
+// checks the property values against the schema of the parent database.
+func (r *notionPageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Nothing to do on destroy.
+		return
+	}
+	if unknownModelValues(req.Plan.Raw, "icon", "cover", "properties") {
+		// The computed values stay unknown until the values they derive
+		// from are known.
+		return
+	}
+
+	var plan notionPageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if title, ok := pageTitleValue(plan.Properties); ok {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("title"), title)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// The content's blocks only change with the content.
+	if plan.Content.IsNull() {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("content_block_ids"), types.ListNull(types.StringType))
+		resp.Diagnostics.Append(diags...)
+	} else if !req.State.Raw.IsNull() {
+		var state notionPageResourceModel
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.Content.Equal(state.Content) {
+			diags = resp.Plan.SetAttribute(ctx, path.Root("content_block_ids"), state.BlockIDs)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+
+	if len(plan.Properties) == 0 || r.client == nil ||
+		plan.ParentType.ValueString() != string(notionapi.ParentTypeDatabaseID) || plan.ParentID.IsUnknown() {
+		return
+	}
+	db, err := getDatabase(ctx, r.client, notionapi.DatabaseID(plan.ParentID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read parent database",
+			fmt.Sprintf("Failed to get database to validate the page properties: %s", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(validatePagePropertyValues(plan.Properties, path.Root("properties"), db)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *notionPageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan notionPageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	props := expandPagePropertyValues(plan.Properties, nil)
+	if _, ok := pageTitleValue(plan.Properties); !ok {
+		for k, v := range pageTitleProperties(&plan) {
+			props[k] = v
+		}
+	}
+	page, err := r.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+		Parent:     expandParent(plan.ParentType.ValueString(), plan.ParentID.ValueString()),
+		Properties: props,
+		Icon:       expandIcon(plan.Icon),
+		Cover:      expandCover(plan.Cover),
+	})
+	if err != nil {
+		tflog.Debug(ctx, "Failed to create page")
+		resp.Diagnostics.AddError(
+			"Failed to create page",
+			fmt.Sprintf("Failed to create page: %s", err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(page.ID.String())
+	plan.URL = types.StringValue(page.URL)
+	parentType, parentID := flattenParent(page.Parent)
+	plan.CurrentParentID = types.StringValue(parentID)
+	plan.CurrentParentType = types.StringValue(parentType)
+	plan.CreatedTime = types.StringValue(page.CreatedTime.String())
+	plan.Title = types.StringValue(pageTitle(page))
+	setIconCoverComputed(plan.Icon, plan.Cover, page.Icon, page.Cover)
+
+	plan.BlockIDs = types.ListNull(types.StringType)
+	if !plan.Content.IsNull() {
+		created, err := appendBlockTree(ctx, r.client, notionapi.BlockID(page.ID), "", markdownToBlocks(plan.Content.ValueString()))
+		plan.BlockIDs = blockIDList(created)
+		if err != nil {
+			setPartiallyCreated(ctx, resp, plan, "Failed to create page content", fmt.Sprintf("Failed to append page content: %s", err))
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *notionPageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state notionPageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	page, err := r.client.Page.Get(ctx, notionapi.PageID(state.ID.ValueString()))
+	if err != nil {
+		if isNotionNotFound(err) {
+			tflog.Warn(ctx, "Page not found, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		tflog.Debug(ctx, "Failed to read page")
+		resp.Diagnostics.AddError(
+			"Failed to read page",
+			fmt.Sprintf("Failed to get page: %s", err),
+		)
+		return
+	}
+	if page.Archived {
+		tflog.Warn(ctx, "Page is archived, removing it from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(page.ID.String())
+	state.URL = types.StringValue(page.URL)
+	// A page moved outside of Terraform keeps its configured parent, which
+	// would otherwise plan to replace it.
+	parentType, parentID := flattenParent(page.Parent)
+	if state.ParentID.IsNull() {
+		// Imported.
+		state.ParentID = types.StringValue(parentID)
+		state.ParentType = types.StringValue(parentType)
+	}
+	state.CurrentParentID = types.StringValue(parentID)
+	state.CurrentParentType = types.StringValue(parentType)
+	state.CreatedTime = types.StringValue(page.CreatedTime.String())
+	state.Title = types.StringValue(pageTitle(page))
+	state.Icon = flattenIcon(page.Icon)
+	state.Cover = flattenCover(page.Cover)
+	for name, prior := range state.Properties {
+		p, ok := page.Properties[name]
+		if !ok {
+			// The property was renamed or removed from the database.
+			delete(state.Properties, name)
+			continue
+		}
+		state.Properties[name] = flattenPagePropertyValue(p, prior)
+	}
+
+	if !state.Content.IsNull() {
+		blocks, err := pageContent(ctx, r.client, page.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read page content",
+				fmt.Sprintf("Failed to get page content: %s", err),
+			)
+			return
+		}
+		state.BlockIDs = blockIDList(blocks)
+		// Only report drift if the content differs from what the configured
+		// Markdown produces, so that equivalent Markdown is left as written.
+		if content := blocksToMarkdown(blocks); content != blocksToMarkdown(markdownToBlocks(state.Content.ValueString())) {
+			state.Content = types.StringValue(content)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *notionPageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state notionPageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := notionapi.PageID(state.ID.ValueString())
+	updateReq := &notionapi.PageUpdateRequest{
+		Properties: expandPagePropertyValues(plan.Properties, state.Properties),
+	}
+	if _, ok := pageTitleValue(plan.Properties); !ok && !plan.Title.Equal(state.Title) {
+		for k, v := range pageTitleProperties(&plan) {
+			updateReq.Properties[k] = v
+		}
+	}
+	// Page.Update can set the icon and cover but not remove them.
+	var clear []string
+	if !iconEqual(plan.Icon, state.Icon) {
+		if plan.Icon == nil {
+			clear = append(clear, "icon")
+		}
+		updateReq.Icon = expandIcon(plan.Icon)
+	}
+	if !coverEqual(plan.Cover, state.Cover) {
+		if plan.Cover == nil {
+			clear = append(clear, "cover")
+		}
+		updateReq.Cover = expandCover(plan.Cover)
+	}
+
+	page, err := r.client.Page.Update(ctx, id, updateReq)
+	if err == nil && len(clear) > 0 {
+		page, err = clearPageFields(ctx, r.client, id, clear...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update page",
+			fmt.Sprintf("Failed to update page: %s", err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(page.ID.String())
+	plan.URL = types.StringValue(page.URL)
+	plan.CreatedTime = types.StringValue(page.CreatedTime.String())
+	plan.Title = types.StringValue(pageTitle(page))
+	setIconCoverComputed(plan.Icon, plan.Cover, page.Icon, page.Cover)
+
+	// Removing content_markdown stops managing the content, it is kept.
+	plan.BlockIDs = state.BlockIDs
+	if plan.Content.IsNull() {
+		plan.BlockIDs = types.ListNull(types.StringType)
+	} else if !plan.Content.Equal(state.Content) {
+		blocks, err := syncBlockChildren(ctx, r.client, notionapi.BlockID(id), markdownToBlocks(plan.Content.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to update page content",
+				fmt.Sprintf("Failed to update page content: %s", err),
+			)
+			return
+		}
+		plan.BlockIDs = blockIDList(blocks)
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *notionPageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state notionPageResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := archivePage(ctx, r.client, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to delete page",
+			fmt.Sprintf("Failed to archive page: %s", err),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing page by its id or URL.
+func (r *notionPageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseNotionID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected a page id or URL: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *notionPageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*notionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *notionClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// pageTitleProperties returns the properties that set the title of the page
+// described by plan.
+func pageTitleProperties(plan *notionPageResourceModel) notionapi.Properties {
+	return notionapi.Properties{
+		notionPageTitleProperty: notionapi.TitleProperty{
+			Type:  notionapi.PropertyTypeTitle,
+			Title: plainRichText(plan.Title.ValueString()),
+		},
+	}
+}
+
+// pageTitle returns the plain text title of a page.
+func pageTitle(page *notionapi.Page) string {
+	for _, p := range page.Properties {
+		if t, ok := p.(*notionapi.TitleProperty); ok {
+			return richTextPlainText(t.Title)
+		}
+	}
+	return ""
+}
+
+// pageContent returns the blocks of the content of a page, without its child
+// pages and databases.
+func pageContent(ctx context.Context, client *notionClient, id notionapi.ObjectID) ([]notionapi.Block, error) {
+	blocks, err := getBlockChildren(ctx, client, notionapi.BlockID(id))
+	if err != nil {
+		return nil, err
+	}
+	var content []notionapi.Block
+	for _, b := range blocks {
+		if !isChildObjectBlock(b) {
+			content = append(content, b)
+		}
+	}
+	return content, nil
+}
+
+// blockIDList returns the ids of blocks as a list value.
+func blockIDList(blocks []notionapi.Block) types.List {
+	ids := make([]attr.Value, 0, len(blocks))
+	for _, b := range blocks {
+		ids = append(ids, types.StringValue(b.GetID().String()))
+	}
+	return types.ListValueMust(types.StringType, ids)
+}
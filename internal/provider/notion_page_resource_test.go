@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionPageResource(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionPageArchived,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNotionPageResourceConfig(parentID, "Runbook", `icon = { emoji = "📘" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("yoloexp_notion_page.test", "id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "title", "Runbook"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "parent_type", "page_id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "current_parent_type", "page_id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "icon.emoji", "📘"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "yoloexp_notion_page.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNotionPageResourceConfig(parentID, "On-call runbook", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "title", "On-call runbook"),
+					resource.TestCheckNoResourceAttr("yoloexp_notion_page.test", "icon.emoji"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotionPageResourceContent(t *testing.T) {
+	parentID := testAccParentPageID(t)
+	content := "# Paging\n\nAcknowledge within **5 minutes**.\n\n- Check the [dashboard](https://example.com)\n- [ ] Page the secondary\n\n```bash\nkubectl get pods\n```"
+	updated := "# Paging\n\nAcknowledge within **10 minutes**.\n\n- Check the [dashboard](https://example.com)\n- [ ] Page the secondary\n\n```bash\nkubectl get pods\n```"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionPageArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotionPageResourceConfig(parentID, "Runbook", fmt.Sprintf("content_markdown = %q", content)),
+				Check:  resource.TestCheckResourceAttr("yoloexp_notion_page.test", "content_markdown", content),
+			},
+			{
+				Config: testAccNotionPageResourceConfig(parentID, "Runbook", fmt.Sprintf("content_markdown = %q", updated)),
+				Check:  resource.TestCheckResourceAttr("yoloexp_notion_page.test", "content_markdown", updated),
+			},
+		},
+	})
+}
+
+func TestAccNotionPageResourceNestedContent(t *testing.T) {
+	parentID := testAccParentPageID(t)
+	content := "<aside>\n\n🚨 Page the on-call engineer first.\n\n</aside>\n\n" +
+		"<columns>\n<column>\n\n## Primary\n\n- alice\n\n</column>\n<column>\n\n## Secondary\n\n<details>\n<summary>Rotation</summary>\n\n- bob\n  - carol\n\n</details>\n\n</column>\n</columns>"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionPageArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotionPageResourceConfig(parentID, "Escalation", fmt.Sprintf("content_markdown = %q", content)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "content_markdown", content),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.test", "content_block_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotionPageResourceDatabaseRow(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNotionPageArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotionPageResourceRowConfig(parentID, "Write runbook", "2024-03-01", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "title", "Write runbook"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "parent_type", "database_id"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "properties.Due.date.start", "2024-03-01"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "properties.Tags.multi_select.#", "2"),
+				),
+			},
+			{
+				Config: testAccNotionPageResourceRowConfig(parentID, "Review runbook", "2024-03-08", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "title", "Review runbook"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "properties.Due.date.start", "2024-03-08"),
+					resource.TestCheckResourceAttr("yoloexp_notion_page.row", "properties.Done.checkbox", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNotionPageResourceRowConfig(parentID, name, due string, done bool) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_database" "test" {
+  parent_id = %[1]q
+  title     = "Acceptance test rows"
+
+  properties = {
+    Name     = { type = "title" }
+    Due      = { type = "date" }
+    Done     = { type = "checkbox" }
+    Estimate = { type = "number" }
+    Tags     = { type = "multi_select" }
+  }
+}
+
+resource "yoloexp_notion_page" "row" {
+  parent_id   = yoloexp_notion_database.test.id
+  parent_type = "database_id"
+
+  properties = {
+    Name     = { title = %[2]q }
+    Due      = { date = { start = %[3]q } }
+    Done     = { checkbox = %[4]t }
+    Estimate = { number = 3 }
+    Tags     = { multi_select = ["ops", "docs"] }
+  }
+}
+`, parentID, name, due, done)
+}
+
+func testAccNotionPageResourceConfig(parentID, title, extra string) string {
+	return fmt.Sprintf(`
+resource "yoloexp_notion_page" "test" {
+  parent_id = %[1]q
+  title     = %[2]q
+  %[3]s
+}
+`, parentID, title, extra)
+}
+
+// testAccCheckNotionPageArchived verifies that destroyed pages were moved to
+// the trash.
+func testAccCheckNotionPageArchived(s *terraform.State) error {
+	client := notionapi.NewClient(notionapi.Token(os.Getenv("NOTION_SECRET")))
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "yoloexp_notion_page" {
+			continue
+		}
+
+		page, err := client.Page.Get(context.Background(), notionapi.PageID(rs.Primary.ID))
+		if err != nil {
+			if isNotionNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !page.Archived {
+			return fmt.Errorf("page %s is not archived", rs.Primary.ID)
+		}
+	}
+	return nil
+}
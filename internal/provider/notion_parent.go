@@ -0,0 +1,45 @@
+package provider
+
+import "github.com/jomei/notionapi"
+
+// flattenParent returns the type and id of a parent. Workspace parents have
+// no id.
+func flattenParent(p notionapi.Parent) (string, string) {
+	switch p.Type {
+	case notionapi.ParentTypePageID:
+		return string(p.Type), p.PageID.String()
+	case notionapi.ParentTypeDatabaseID:
+		return string(p.Type), p.DatabaseID.String()
+	case notionapi.ParentTypeBlockID:
+		return string(p.Type), p.BlockID.String()
+	case notionapi.ParentTypeWorkspace:
+		return string(p.Type), ""
+	}
+
+	// Older responses may omit the type.
+	switch {
+	case p.PageID != "":
+		return string(notionapi.ParentTypePageID), p.PageID.String()
+	case p.DatabaseID != "":
+		return string(notionapi.ParentTypeDatabaseID), p.DatabaseID.String()
+	case p.BlockID != "":
+		return string(notionapi.ParentTypeBlockID), p.BlockID.String()
+	}
+	return string(p.Type), ""
+}
+
+// expandParent returns the parent object for the given type and id.
+func expandParent(parentType, id string) notionapi.Parent {
+	p := notionapi.Parent{Type: notionapi.ParentType(parentType)}
+	switch p.Type {
+	case notionapi.ParentTypePageID:
+		p.PageID = notionapi.PageID(id)
+	case notionapi.ParentTypeDatabaseID:
+		p.DatabaseID = notionapi.DatabaseID(id)
+	case notionapi.ParentTypeBlockID:
+		p.BlockID = notionapi.BlockID(id)
+	case notionapi.ParentTypeWorkspace:
+		p.Workspace = true
+	}
+	return p
+}
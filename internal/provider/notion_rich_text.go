@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+const (
+	// notionMaxRichTextLength is the most characters Notion accepts in the
+	// text of one rich text object.
+	notionMaxRichTextLength = 2000
+	// notionMaxRichTextObjects is the most rich text objects Notion accepts
+	// in one value.
+	notionMaxRichTextObjects = 100
+)
+
+// plainTextValidator limits plain text to what fits in the rich text objects
+// of one value, see plainRichText.
+func plainTextValidator() validator.String {
+	return stringvalidator.UTF8LengthAtMost(notionMaxRichTextLength * notionMaxRichTextObjects)
+}
+
+type notionRichTextModel struct {
+	Text          types.String `tfsdk:"text"`
+	Link          types.String `tfsdk:"link"`
+	Bold          types.Bool   `tfsdk:"bold"`
+	Italic        types.Bool   `tfsdk:"italic"`
+	Strikethrough types.Bool   `tfsdk:"strikethrough"`
+	Underline     types.Bool   `tfsdk:"underline"`
+	Code          types.Bool   `tfsdk:"code"`
+	Color         types.String `tfsdk:"color"`
+}
+
+// richTextResourceAttribute returns the schema of a rich text attribute, a
+// list of styled text segments.
+func richTextResourceAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(notionMaxRichTextObjects),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"text": schema.StringAttribute{
+					MarkdownDescription: "The text content of the segment, at most 2000 characters.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.UTF8LengthAtMost(notionMaxRichTextLength),
+					},
+				},
+				"link": schema.StringAttribute{
+					MarkdownDescription: "The URL the segment links to.",
+					Optional:            true,
+				},
+				"bold": schema.BoolAttribute{
+					MarkdownDescription: "Whether the segment is bold.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"italic": schema.BoolAttribute{
+					MarkdownDescription: "Whether the segment is italic.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"strikethrough": schema.BoolAttribute{
+					MarkdownDescription: "Whether the segment is struck through.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"underline": schema.BoolAttribute{
+					MarkdownDescription: "Whether the segment is underlined.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"code": schema.BoolAttribute{
+					MarkdownDescription: "Whether the segment is formatted as inline code.",
+					Optional:            true,
+					Computed:            true,
+					Default:             booldefault.StaticBool(false),
+				},
+				"color": schema.StringAttribute{
+					MarkdownDescription: "The color of the segment.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString(string(notionapi.ColorDefault)),
+				},
+			},
+		},
+	}
+}
+
+// expandRichText converts rich text segments into Notion rich text objects.
+func expandRichText(segments []notionRichTextModel) []notionapi.RichText {
+	rt := make([]notionapi.RichText, 0, len(segments))
+	for _, s := range segments {
+		text := &notionapi.Text{Content: s.Text.ValueString()}
+		if !s.Link.IsNull() && s.Link.ValueString() != "" {
+			text.Link = &notionapi.Link{Url: s.Link.ValueString()}
+		}
+		rt = append(rt, notionapi.RichText{
+			Type: notionapi.ObjectTypeText,
+			Text: text,
+			Annotations: &notionapi.Annotations{
+				Bold:          s.Bold.ValueBool(),
+				Italic:        s.Italic.ValueBool(),
+				Strikethrough: s.Strikethrough.ValueBool(),
+				Underline:     s.Underline.ValueBool(),
+				Code:          s.Code.ValueBool(),
+				Color:         notionapi.Color(s.Color.ValueString()),
+			},
+		})
+	}
+	return rt
+}
+
+// plainRichText converts plain text into unstyled rich text objects, one
+// for every notionMaxRichTextLength characters.
+func plainRichText(text string) []notionapi.RichText {
+	rt := []notionapi.RichText{}
+	for chars := []rune(text); len(chars) > 0; {
+		n := min(len(chars), notionMaxRichTextLength)
+		rt = append(rt, notionapi.RichText{
+			Type: notionapi.ObjectTypeText,
+			Text: &notionapi.Text{Content: string(chars[:n])},
+		})
+		chars = chars[n:]
+	}
+	return rt
+}
+
+// flattenRichText converts Notion rich text objects into rich text segments.
+func flattenRichText(rt []notionapi.RichText) []notionRichTextModel {
+	segments := make([]notionRichTextModel, 0, len(rt))
+	for _, r := range rt {
+		s := notionRichTextModel{
+			Text:          types.StringValue(r.PlainText),
+			Link:          types.StringNull(),
+			Bold:          types.BoolValue(false),
+			Italic:        types.BoolValue(false),
+			Strikethrough: types.BoolValue(false),
+			Underline:     types.BoolValue(false),
+			Code:          types.BoolValue(false),
+			Color:         types.StringValue(string(notionapi.ColorDefault)),
+		}
+		if r.Text != nil {
+			s.Text = types.StringValue(r.Text.Content)
+			if r.Text.Link != nil && r.Text.Link.Url != "" {
+				s.Link = types.StringValue(r.Text.Link.Url)
+			}
+		}
+		if a := r.Annotations; a != nil {
+			s.Bold = types.BoolValue(a.Bold)
+			s.Italic = types.BoolValue(a.Italic)
+			s.Strikethrough = types.BoolValue(a.Strikethrough)
+			s.Underline = types.BoolValue(a.Underline)
+			s.Code = types.BoolValue(a.Code)
+			if a.Color != "" {
+				s.Color = types.StringValue(string(a.Color))
+			}
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+// richTextPlainText returns the concatenated plain text of Notion rich text
+// objects.
+func richTextPlainText(rt []notionapi.RichText) string {
+	var sb strings.Builder
+	for _, r := range rt {
+		switch {
+		case r.PlainText != "":
+			sb.WriteString(r.PlainText)
+		case r.Text != nil:
+			sb.WriteString(r.Text.Content)
+		}
+	}
+	return sb.String()
+}
+
+// segmentsPlainText returns the concatenated text of rich text segments.
+func segmentsPlainText(segments []notionRichTextModel) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteString(s.Text.ValueString())
+	}
+	return sb.String()
+}
+
+// richTextKnown reports whether all text of the segments is known.
+func richTextKnown(segments []notionRichTextModel) bool {
+	for _, s := range segments {
+		if s.Text.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// richTextEqual reports whether two lists of rich text segments are equal.
+func richTextEqual(a, b []notionRichTextModel) bool {
+	if (a == nil) != (b == nil) || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Text.Equal(b[i].Text) ||
+			!a[i].Link.Equal(b[i].Link) ||
+			!a[i].Bold.Equal(b[i].Bold) ||
+			!a[i].Italic.Equal(b[i].Italic) ||
+			!a[i].Strikethrough.Equal(b[i].Strikethrough) ||
+			!a[i].Underline.Equal(b[i].Underline) ||
+			!a[i].Code.Equal(b[i].Code) ||
+			!a[i].Color.Equal(b[i].Color) {
+			return false
+		}
+	}
+	return true
+}
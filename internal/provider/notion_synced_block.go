@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+// notionSyncedBlockModel is an original synced block, with its content, or a
+// copy of one.
+type notionSyncedBlockModel struct {
+	ContentMarkdown types.String `tfsdk:"content_markdown"`
+	SyncedFrom      types.String `tfsdk:"synced_from"`
+	CopyParentIDs   types.Set    `tfsdk:"copy_parent_ids"`
+	Copies          types.Map    `tfsdk:"copies"`
+	CopyPageIDs     types.Set    `tfsdk:"copy_page_ids"`
+	PageID          types.String `tfsdk:"page_id"`
+}
+
+// syncedBlockAttribute returns the schema of the synced block kind of the
+// block resource.
+func syncedBlockAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "A synced block, whose content shows on every page holding a copy of it. Set `content_markdown` to create the original, " +
+			"and `synced_from` to create a copy of an original on another page.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"content_markdown": schema.StringAttribute{
+				MarkdownDescription: "The content of the original, in the Markdown of the page resource's `content_markdown`. " +
+					"Exactly one of `content_markdown` and `synced_from` must be set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("synced_from")),
+				},
+			},
+			"synced_from": schema.StringAttribute{
+				MarkdownDescription: "The id of the original synced block this block is a copy of, for a copy managed apart from its original. Changing it re-creates the block. " +
+					"If the original is deleted, refreshing the copy warns about it.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"copy_parent_ids": schema.SetAttribute{
+				MarkdownDescription: "The ids of the pages or blocks to add a copy of the original to. The copies are managed with the original: " +
+					"they are appended to their parents, re-created if deleted, and deleted before the original.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("synced_from")),
+				},
+			},
+			"copies": schema.MapAttribute{
+				MarkdownDescription: "The ids of the copies of the original, keyed by the ids in `copy_parent_ids`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"copy_page_ids": schema.SetAttribute{
+				MarkdownDescription: "The ids of the pages showing the copies in `copies`, through any parent blocks.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"page_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the page holding the block, through any parent blocks.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// expandSyncedBlock converts a synced block model into a Notion block: a copy
+// of its original, or an original with its content as children.
+func expandSyncedBlock(m *notionSyncedBlockModel) notionapi.Block {
+	if !m.SyncedFrom.IsNull() {
+		return syncedCopy(notionapi.BlockID(m.SyncedFrom.ValueString()))
+	}
+	return &notionapi.SyncedBlock{
+		BasicBlock:  newBasicBlock(notionapi.BlockTypeSyncedBlock),
+		SyncedBlock: notionapi.Synced{Children: markdownToBlocks(m.ContentMarkdown.ValueString())},
+	}
+}
+
+// syncedCopy returns a copy of an original synced block.
+func syncedCopy(original notionapi.BlockID) notionapi.Block {
+	return &notionapi.SyncedBlock{
+		BasicBlock:  newBasicBlock(notionapi.BlockTypeSyncedBlock),
+		SyncedBlock: notionapi.Synced{SyncedFrom: &notionapi.SyncedFrom{BlockID: original}},
+	}
+}
+
+// flattenSyncedBlock converts a Notion synced block, with its children if it
+// is an original, into a synced block model. Content and ids equivalent to
+// the prior model are kept as written.
+func flattenSyncedBlock(s notionapi.Synced, prior *notionSyncedBlockModel) *notionSyncedBlockModel {
+	m := &notionSyncedBlockModel{
+		ContentMarkdown: types.StringNull(),
+		SyncedFrom:      types.StringNull(),
+		CopyParentIDs:   types.SetNull(types.StringType),
+		Copies:          types.MapNull(types.StringType),
+		CopyPageIDs:     types.SetNull(types.StringType),
+		PageID:          types.StringNull(),
+	}
+	if prior != nil {
+		m.PageID = prior.PageID
+	}
+
+	if s.SyncedFrom != nil {
+		id := s.SyncedFrom.BlockID.String()
+		if prior != nil && notionIDEqual(prior.SyncedFrom.ValueString(), id) {
+			id = prior.SyncedFrom.ValueString()
+		}
+		m.SyncedFrom = types.StringValue(id)
+		return m
+	}
+
+	// The copies are refreshed by readSyncedCopies.
+	if prior != nil {
+		m.CopyParentIDs, m.Copies, m.CopyPageIDs = prior.CopyParentIDs, prior.Copies, prior.CopyPageIDs
+	}
+	content := blocksToMarkdown(s.Children)
+	if prior != nil && !prior.ContentMarkdown.IsNull() && content == blocksToMarkdown(markdownToBlocks(prior.ContentMarkdown.ValueString())) {
+		content = prior.ContentMarkdown.ValueString()
+	}
+	m.ContentMarkdown = types.StringValue(content)
+	return m
+}
+
+// isSyncedOriginal reports whether a block is an original synced block, whose
+// children are its content. The children of a copy are the content of its
+// original.
+func isSyncedOriginal(b notionapi.Block) bool {
+	s, ok := b.(*notionapi.SyncedBlock)
+	return ok && s.SyncedBlock.SyncedFrom == nil
+}
+
+// checkSyncedOriginal returns an error if the block with the given id is not
+// an original synced block that copies can be made of.
+func checkSyncedOriginal(ctx context.Context, client *notionClient, id notionapi.BlockID) error {
+	b, err := client.Block.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get original synced block %s: %w", id, err)
+	}
+	switch s, ok := b.(*notionapi.SyncedBlock); {
+	case b.GetArchived():
+		return fmt.Errorf("original synced block %s is deleted", id)
+	case !ok:
+		return fmt.Errorf("block %s is a %s block, not a synced block", id, b.GetType())
+	case s.SyncedBlock.SyncedFrom != nil:
+		return fmt.Errorf("block %s is a copy of synced block %s, copies can only be made of the original", id, s.SyncedBlock.SyncedFrom.BlockID)
+	}
+	return nil
+}
+
+// syncedOriginalExists reports whether the original synced block with the
+// given id exists and is not deleted.
+func syncedOriginalExists(ctx context.Context, client *notionClient, id notionapi.BlockID) (bool, error) {
+	b, err := client.Block.Get(ctx, id)
+	if isNotionNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !b.GetArchived(), nil
+}
+
+// blockPageID returns the id of the page holding a block, following the
+// parents of nested blocks.
+func blockPageID(ctx context.Context, client *notionClient, b notionapi.Block) (string, error) {
+	for {
+		p := b.GetParent()
+		if p == nil {
+			return "", fmt.Errorf("block %s has no parent", b.GetID())
+		}
+		t, id := flattenParent(*p)
+		if t != string(notionapi.ParentTypeBlockID) {
+			return id, nil
+		}
+		parent, err := client.Block.Get(ctx, notionapi.BlockID(id))
+		if err != nil {
+			return "", err
+		}
+		b = parent
+	}
+}
+
+// syncedCopies returns the copies of a synced block model, keyed by parent.
+func syncedCopies(ctx context.Context, m *notionSyncedBlockModel) (map[string]string, diag.Diagnostics) {
+	copies := map[string]string{}
+	if m.Copies.IsNull() || m.Copies.IsUnknown() {
+		return copies, nil
+	}
+	diags := m.Copies.ElementsAs(ctx, &copies, false)
+	return copies, diags
+}
+
+// setSyncedCopies sets the copies of a synced block model and the pages
+// showing them. They are null if the original has no copies to manage.
+func setSyncedCopies(m *notionSyncedBlockModel, copies map[string]string, pages []string) {
+	if m.CopyParentIDs.IsNull() && len(copies) == 0 {
+		m.Copies = types.MapNull(types.StringType)
+		m.CopyPageIDs = types.SetNull(types.StringType)
+		return
+	}
+	ids := make(map[string]attr.Value, len(copies))
+	for parent, id := range copies {
+		ids[parent] = types.StringValue(id)
+	}
+	pageIDs := make([]attr.Value, 0, len(pages))
+	for _, id := range pages {
+		pageIDs = append(pageIDs, types.StringValue(id))
+	}
+	m.Copies = types.MapValueMust(types.StringType, ids)
+	m.CopyPageIDs = types.SetValueMust(types.StringType, pageIDs)
+}
+
+// planSyncedCopies returns the planned copies of a synced block and the pages
+// showing them: those in state if the planned parents have a copy each, and
+// unknown otherwise so that the apply adds the missing copies.
+func planSyncedCopies(ctx context.Context, plan, state *notionSyncedBlockModel) (types.Map, types.Set, diag.Diagnostics) {
+	unknown := func() (types.Map, types.Set, diag.Diagnostics) {
+		return types.MapUnknown(types.StringType), types.SetUnknown(types.StringType), nil
+	}
+	if plan.CopyParentIDs.IsNull() {
+		if state != nil && !state.Copies.IsNull() && len(state.Copies.Elements()) > 0 {
+			return unknown()
+		}
+		return types.MapNull(types.StringType), types.SetNull(types.StringType), nil
+	}
+	if state == nil || plan.CopyParentIDs.IsUnknown() || state.Copies.IsNull() {
+		return unknown()
+	}
+
+	var parents []string
+	diags := plan.CopyParentIDs.ElementsAs(ctx, &parents, true)
+	if diags.HasError() {
+		return types.MapUnknown(types.StringType), types.SetUnknown(types.StringType), diags
+	}
+	copies := state.Copies.Elements()
+	if len(copies) != len(parents) {
+		return unknown()
+	}
+	for _, parent := range parents {
+		if _, ok := copies[parent]; !ok {
+			return unknown()
+		}
+	}
+	return state.Copies, state.CopyPageIDs, nil
+}
+
+// applySyncedCopies makes the copies of an original synced block match its
+// planned parents, given the copies in state keyed by parent, and sets them
+// in the plan. Copies no longer planned are deleted and missing ones are
+// appended to their parents. The copies are set even on error, so that the
+// ones made are not orphaned.
+func applySyncedCopies(ctx context.Context, client *notionClient, original notionapi.BlockID, plan *notionSyncedBlockModel, copies map[string]string) error {
+	var parents []string
+	if !plan.CopyParentIDs.IsNull() {
+		if diags := plan.CopyParentIDs.ElementsAs(ctx, &parents, false); diags.HasError() {
+			return fmt.Errorf("invalid copy_parent_ids")
+		}
+	}
+
+	err := deleteSyncedCopies(ctx, client, copies, parents)
+	if err == nil {
+		for _, parent := range parents {
+			if _, ok := copies[parent]; ok {
+				continue
+			}
+			created, appendErr := appendBlockChildren(ctx, client, notionapi.BlockID(parent), "", []notionapi.Block{syncedCopy(original)})
+			if appendErr == nil && len(created) == 0 {
+				appendErr = fmt.Errorf("Notion returned no block")
+			}
+			if appendErr != nil {
+				err = fmt.Errorf("failed to add a copy to %s: %w", parent, appendErr)
+				break
+			}
+			copies[parent] = created[0].GetID().String()
+		}
+	}
+
+	pages, readErr := readSyncedCopies(ctx, client, copies)
+	if err == nil {
+		err = readErr
+	}
+	setSyncedCopies(plan, copies, pages)
+	return err
+}
+
+// deleteSyncedCopies deletes the copies whose parent is not in keep and
+// removes them from copies.
+func deleteSyncedCopies(ctx context.Context, client *notionClient, copies map[string]string, keep []string) error {
+	for parent, id := range copies {
+		if slices.Contains(keep, parent) {
+			continue
+		}
+		_, err := client.Block.Delete(ctx, notionapi.BlockID(id))
+		if err != nil && !isNotionNotFound(err) && !blockDeleted(ctx, client, notionapi.BlockID(id)) {
+			return fmt.Errorf("failed to delete the copy in %s: %w", parent, err)
+		}
+		delete(copies, parent)
+	}
+	return nil
+}
+
+// readSyncedCopies removes the copies that were deleted from copies, and
+// returns the ids of the pages showing the others, sorted.
+func readSyncedCopies(ctx context.Context, client *notionClient, copies map[string]string) ([]string, error) {
+	var pages []string
+	for parent, id := range copies {
+		b, err := client.Block.Get(ctx, notionapi.BlockID(id))
+		if isNotionNotFound(err) || (err == nil && b.GetArchived()) {
+			delete(copies, parent)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the copy in %s: %w", parent, err)
+		}
+		page, err := blockPageID(ctx, client, b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the page of the copy in %s: %w", parent, err)
+		}
+		if !slices.Contains(pages, page) {
+			pages = append(pages, page)
+		}
+	}
+	sort.Strings(pages)
+	return pages, nil
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionBlockResourceSyncedBlock(t *testing.T) {
+	parentID := testAccParentPageID(t)
+	config := func(content string) string {
+		return testAccNotionBlockResourceConfig(parentID, fmt.Sprintf(`synced_block = { content_markdown = %q }`, content)) + `
+resource "yoloexp_notion_page" "copy" {
+  parent_id = yoloexp_notion_page.test.id
+  title     = "Acceptance test synced block copy"
+}
+
+resource "yoloexp_notion_block" "copy" {
+  parent_id    = yoloexp_notion_page.copy.id
+  synced_block = { synced_from = yoloexp_notion_block.test.id }
+}
+`
+	}
+	managedConfig := testAccNotionBlockResourceConfig(parentID, `synced_block = {
+    content_markdown = "## Escalation"
+    copy_parent_ids  = [yoloexp_notion_page.copy.id]
+  }`) + `
+resource "yoloexp_notion_page" "copy" {
+  parent_id = yoloexp_notion_page.test.id
+  title     = "Acceptance test synced block copy"
+}
+`
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: config("## Escalation\n\n- alice"),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "type", "synced_block"),
+					tfresource.TestCheckResourceAttrPair("yoloexp_notion_block.test", "synced_block.page_id", "yoloexp_notion_page.test", "id"),
+					tfresource.TestCheckResourceAttrPair("yoloexp_notion_block.copy", "synced_block.synced_from", "yoloexp_notion_block.test", "id"),
+					tfresource.TestCheckResourceAttrPair("yoloexp_notion_block.copy", "synced_block.page_id", "yoloexp_notion_page.copy", "id"),
+					tfresource.TestCheckNoResourceAttr("yoloexp_notion_block.copy", "synced_block.content_markdown"),
+				),
+			},
+			{
+				Config: config("## Escalation\n\n- alice\n- bob"),
+				Check:  tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "synced_block.content_markdown", "## Escalation\n\n- alice\n- bob"),
+			},
+			{
+				Config: managedConfig,
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "synced_block.copy_page_ids.#", "1"),
+					tfresource.TestCheckTypeSetElemAttrPair("yoloexp_notion_block.test", "synced_block.copy_page_ids.*", "yoloexp_notion_page.copy", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestSyncedBlock(t *testing.T) {
+	original := expandSyncedBlock(&notionSyncedBlockModel{
+		ContentMarkdown: types.StringValue("* alice\n* bob"),
+		SyncedFrom:      types.StringNull(),
+	}).(*notionapi.SyncedBlock)
+	if original.SyncedBlock.SyncedFrom != nil || len(original.SyncedBlock.Children) != 2 {
+		t.Fatalf("expandSyncedBlock() = %+v, want an original with 2 children", original.SyncedBlock)
+	}
+	if !isSyncedOriginal(original) {
+		t.Error("isSyncedOriginal() = false for an original")
+	}
+
+	prior := &notionSyncedBlockModel{
+		ContentMarkdown: types.StringValue("* alice\n* bob"),
+		SyncedFrom:      types.StringNull(),
+		PageID:          types.StringValue("page"),
+	}
+	m := flattenSyncedBlock(original.SyncedBlock, prior)
+	if m.ContentMarkdown != prior.ContentMarkdown || m.PageID != prior.PageID || !m.SyncedFrom.IsNull() {
+		t.Errorf("flattenSyncedBlock() = %+v, want the prior content kept", m)
+	}
+	m = flattenSyncedBlock(original.SyncedBlock, nil)
+	if got := m.ContentMarkdown.ValueString(); got != "- alice\n- bob" {
+		t.Errorf("content_markdown = %q on import", got)
+	}
+
+	id := "8263e83034244754a8011d971606cd6c"
+	copied := expandSyncedBlock(&notionSyncedBlockModel{
+		ContentMarkdown: types.StringNull(),
+		SyncedFrom:      types.StringValue(id),
+	}).(*notionapi.SyncedBlock)
+	if copied.SyncedBlock.SyncedFrom == nil || len(copied.SyncedBlock.Children) != 0 || isSyncedOriginal(copied) {
+		t.Fatalf("expandSyncedBlock() = %+v, want a copy without children", copied.SyncedBlock)
+	}
+	copied.SyncedBlock.SyncedFrom.BlockID = "8263e830-3424-4754-a801-1d971606cd6c"
+	m = flattenSyncedBlock(copied.SyncedBlock, &notionSyncedBlockModel{SyncedFrom: types.StringValue(id)})
+	if m.SyncedFrom.ValueString() != id || !m.ContentMarkdown.IsNull() {
+		t.Errorf("flattenSyncedBlock() = %+v, want the prior id kept", m)
+	}
+}
+
+func TestPlanSyncedCopies(t *testing.T) {
+	ctx := context.Background()
+	parents := func(ids ...string) types.Set {
+		v, _ := types.SetValueFrom(ctx, types.StringType, ids)
+		return v
+	}
+	copies, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"parent": "copy"})
+	pages, _ := types.SetValueFrom(ctx, types.StringType, []string{"page"})
+	state := &notionSyncedBlockModel{CopyParentIDs: parents("parent"), Copies: copies, CopyPageIDs: pages}
+
+	tests := []struct {
+		name        string
+		plan, state *notionSyncedBlockModel
+		wantKnown   bool
+		wantNull    bool
+	}{
+		{
+			name:      "no copies",
+			plan:      &notionSyncedBlockModel{CopyParentIDs: types.SetNull(types.StringType)},
+			wantKnown: true,
+			wantNull:  true,
+		},
+		{
+			name:  "new copies",
+			plan:  &notionSyncedBlockModel{CopyParentIDs: parents("parent")},
+			state: nil,
+		},
+		{
+			name:      "unchanged copies",
+			plan:      &notionSyncedBlockModel{CopyParentIDs: parents("parent")},
+			state:     state,
+			wantKnown: true,
+		},
+		{
+			name:  "added copy",
+			plan:  &notionSyncedBlockModel{CopyParentIDs: parents("parent", "other")},
+			state: state,
+		},
+		{
+			name:  "removed copies",
+			plan:  &notionSyncedBlockModel{CopyParentIDs: types.SetNull(types.StringType)},
+			state: state,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCopies, gotPages, diags := planSyncedCopies(ctx, tt.plan, tt.state)
+			if diags.HasError() {
+				t.Fatalf("planSyncedCopies() diags = %v", diags)
+			}
+			if known := !gotCopies.IsUnknown() && !gotPages.IsUnknown(); known != tt.wantKnown {
+				t.Errorf("planSyncedCopies() = %v, %v, want known %t", gotCopies, gotPages, tt.wantKnown)
+			}
+			if tt.wantKnown && (gotCopies.IsNull() != tt.wantNull || gotPages.IsNull() != tt.wantNull) {
+				t.Errorf("planSyncedCopies() = %v, %v, want null %t", gotCopies, gotPages, tt.wantNull)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jomei/notionapi"
+)
+
+type notionTableBlockModel struct {
+	SourceFile      types.String              `tfsdk:"source_file"`
+	Columns         []types.String            `tfsdk:"columns"`
+	Rows            []map[string]types.String `tfsdk:"rows"`
+	HasColumnHeader types.Bool                `tfsdk:"has_column_header"`
+	HasRowHeader    types.Bool                `tfsdk:"has_row_header"`
+	TableWidth      types.Int64               `tfsdk:"table_width"`
+	ContentSHA256   types.String              `tfsdk:"content_sha256"`
+
+	// Grid is the text of the cells of the table, row by row, set by
+	// loadTableGrid.
+	Grid [][]string `tfsdk:"-"`
+}
+
+// tableBlockAttribute returns the schema of the table kind of the block
+// resource.
+func tableBlockAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "A table, built from a CSV file or a list of rows. Cells are plain text. Rows are updated in place; changing the number of columns re-creates the block.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"source_file": schema.StringAttribute{
+				MarkdownDescription: "The path of a CSV file whose records are the rows of the table, the header included. Exactly one of `source_file` and `rows` must be set.",
+				Optional:            true,
+			},
+			"rows": schema.ListAttribute{
+				MarkdownDescription: "The rows of the table, each a map of column names to cell text.",
+				Optional:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+				Validators: []validator.List{
+					listvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("source_file")),
+				},
+			},
+			"columns": schema.ListAttribute{
+				MarkdownDescription: "The columns of the table in order, naming the keys of `rows`. With `has_column_header`, they are the first row. Defaults to all keys of `rows`, sorted.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("source_file")),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"has_column_header": schema.BoolAttribute{
+				MarkdownDescription: "Whether the first row is styled as a header. For `rows`, it also adds the column names as the first row.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"has_row_header": schema.BoolAttribute{
+				MarkdownDescription: "Whether the first column is styled as a header.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"table_width": schema.Int64Attribute{
+				MarkdownDescription: "The number of columns of the table.",
+				Computed:            true,
+			},
+			"content_sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 checksum of the cells of the table. It changes when the rows change, or when the cells are edited in Notion.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// loadTableGrid sets the grid of a table model from its rows or its CSV
+// file. It returns false if the rows are not known yet.
+func loadTableGrid(m *notionTableBlockModel) (bool, error) {
+	if m.SourceFile.IsUnknown() || m.HasColumnHeader.IsUnknown() {
+		return false, nil
+	}
+	var grid [][]string
+	if !m.SourceFile.IsNull() {
+		g, err := readCSVGrid(m.SourceFile.ValueString())
+		if err != nil {
+			return false, err
+		}
+		grid = g
+	} else {
+		columns, ok := tableColumns(m)
+		if !ok {
+			return false, nil
+		}
+		if m.HasColumnHeader.ValueBool() {
+			grid = append(grid, columns)
+		}
+		for i, row := range m.Rows {
+			cells := make([]string, len(columns))
+			for k, v := range row {
+				if v.IsUnknown() {
+					return false, nil
+				}
+				j := slices.Index(columns, k)
+				if j < 0 {
+					return false, fmt.Errorf("row %d has a value for %q, which is not one of the columns", i, k)
+				}
+				cells[j] = v.ValueString()
+			}
+			grid = append(grid, cells)
+		}
+	}
+	if len(grid) == 0 {
+		return false, errors.New("the table has no rows")
+	}
+
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return false, errors.New("the table has no columns")
+	}
+	for i, row := range grid {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		grid[i] = row
+	}
+	m.Grid = grid
+	return true, nil
+}
+
+// tableColumns returns the columns of a table built from rows: the configured
+// ones, or all keys of the rows sorted. It returns false if they are not
+// known yet.
+func tableColumns(m *notionTableBlockModel) ([]string, bool) {
+	if m.Columns != nil {
+		columns := make([]string, 0, len(m.Columns))
+		for _, c := range m.Columns {
+			if c.IsUnknown() {
+				return nil, false
+			}
+			columns = append(columns, c.ValueString())
+		}
+		return columns, true
+	}
+	var columns []string
+	for _, row := range m.Rows {
+		for k := range row {
+			if !slices.Contains(columns, k) {
+				columns = append(columns, k)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns, true
+}
+
+// readCSVGrid returns the records of a CSV file.
+func readCSVGrid(name string) ([][]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	var grid [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return grid, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		grid = append(grid, record)
+	}
+}
+
+// gridSHA256 returns the checksum of the cells of a table.
+func gridSHA256(grid [][]string) string {
+	data, _ := json.Marshal(grid)
+	return contentSHA256(string(data))
+}
+
+// setTableComputed sets the computed attributes of a table model from its
+// grid.
+func setTableComputed(m *notionTableBlockModel) {
+	m.TableWidth = types.Int64Value(int64(len(m.Grid[0])))
+	m.ContentSHA256 = types.StringValue(gridSHA256(m.Grid))
+}
+
+func expandTable(m *notionTableBlockModel) notionapi.Block {
+	return &notionapi.TableBlock{
+		BasicBlock: newBasicBlock(notionapi.BlockTypeTableBlock),
+		Table: notionapi.Table{
+			TableWidth:      len(m.Grid[0]),
+			HasColumnHeader: m.HasColumnHeader.ValueBool(),
+			HasRowHeader:    m.HasRowHeader.ValueBool(),
+			Children:        tableRowBlocks(m.Grid),
+		},
+	}
+}
+
+func tableRowBlocks(grid [][]string) []notionapi.Block {
+	rows := make([]notionapi.Block, 0, len(grid))
+	for _, cells := range grid {
+		rows = append(rows, &notionapi.TableRowBlock{
+			BasicBlock: newBasicBlock(notionapi.BlockTypeTableRowBlock),
+			TableRow:   notionapi.TableRow{Cells: tableCells(cells)},
+		})
+	}
+	return rows
+}
+
+func tableCells(cells []string) [][]notionapi.RichText {
+	rt := make([][]notionapi.RichText, 0, len(cells))
+	for _, c := range cells {
+		rt = append(rt, splitRichText(c, nil))
+	}
+	return rt
+}
+
+// tableGrid returns the text of the cells of the rows of a Notion table.
+func tableGrid(rows []notionapi.Block) [][]string {
+	grid := make([][]string, 0, len(rows))
+	for _, b := range rows {
+		row, ok := b.(*notionapi.TableRowBlock)
+		if !ok {
+			continue
+		}
+		cells := make([]string, 0, len(row.TableRow.Cells))
+		for _, c := range row.TableRow.Cells {
+			cells = append(cells, richTextPlainText(c))
+		}
+		grid = append(grid, cells)
+	}
+	return grid
+}
+
+// flattenTable converts a Notion table into a table model. If the cells no
+// longer match the prior model, only its checksum is changed so that the next
+// plan writes the rows again. Without a prior model, as on import, the rows
+// are keyed by the header row, or by column numbers if there is none.
+func flattenTable(t notionapi.Table, prior *notionTableBlockModel) *notionTableBlockModel {
+	grid := tableGrid(t.Children)
+	m := &notionTableBlockModel{
+		SourceFile:      types.StringNull(),
+		HasColumnHeader: types.BoolValue(t.HasColumnHeader),
+		HasRowHeader:    types.BoolValue(t.HasRowHeader),
+		TableWidth:      types.Int64Value(int64(t.TableWidth)),
+		ContentSHA256:   types.StringValue(gridSHA256(grid)),
+	}
+	if prior != nil {
+		m.SourceFile = prior.SourceFile
+		m.Columns = prior.Columns
+		m.Rows = prior.Rows
+		return m
+	}
+
+	columns := make([]types.String, t.TableWidth)
+	for i := range columns {
+		columns[i] = types.StringValue(fmt.Sprintf("column_%d", i+1))
+	}
+	if t.HasColumnHeader && len(grid) > 0 {
+		for i, c := range grid[0] {
+			columns[i] = types.StringValue(c)
+		}
+		grid = grid[1:]
+	}
+	m.Columns = columns
+	m.Rows = make([]map[string]types.String, 0, len(grid))
+	for _, cells := range grid {
+		row := make(map[string]types.String, len(cells))
+		for i, c := range cells {
+			if c != "" {
+				row[columns[i].ValueString()] = types.StringValue(c)
+			}
+		}
+		m.Rows = append(m.Rows, row)
+	}
+	return m
+}
+
+// syncTableRows makes the rows of a table match a grid, updating rows in
+// place, appending missing rows and deleting extra ones.
+func syncTableRows(ctx context.Context, client *notionClient, table notionapi.BlockID, grid [][]string) error {
+	current, err := getBlockChildren(ctx, client, table)
+	if err != nil {
+		return err
+	}
+	have := tableGrid(current)
+
+	for i := 0; i < min(len(current), len(grid)); i++ {
+		if slices.Equal(have[i], grid[i]) {
+			continue
+		}
+		_, err := client.Block.Update(ctx, current[i].GetID(), &notionapi.BlockUpdateRequest{
+			TableRow: &notionapi.TableRow{Cells: tableCells(grid[i])},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(grid) > len(current) {
+		var after notionapi.BlockID
+		if len(current) > 0 {
+			after = current[len(current)-1].GetID()
+		}
+		_, err := appendBlockChildren(ctx, client, table, after, tableRowBlocks(grid[len(current):]))
+		return err
+	}
+	for _, b := range current[len(grid):] {
+		if _, err := client.Block.Delete(ctx, b.GetID()); err != nil && !isNotionNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
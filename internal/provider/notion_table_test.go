@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jomei/notionapi"
+)
+
+func TestAccNotionBlockResourceTable(t *testing.T) {
+	parentID := testAccParentPageID(t)
+
+	tfresource.Test(t, tfresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []tfresource.TestStep{
+			{
+				Config: testAccNotionBlockResourceConfig(parentID, `table = {
+    columns = ["Service", "Owner"]
+    rows = [
+      { Service = "api", Owner = "team-a" },
+      { Service = "web", Owner = "team-b" },
+    ]
+  }`),
+				Check: tfresource.ComposeAggregateTestCheckFunc(
+					tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "table.table_width", "2"),
+					tfresource.TestCheckResourceAttrSet("yoloexp_notion_block.test", "table.content_sha256"),
+				),
+			},
+			{
+				Config: testAccNotionBlockResourceConfig(parentID, `table = {
+    columns = ["Service", "Owner"]
+    rows = [
+      { Service = "api", Owner = "team-c" },
+    ]
+  }`),
+				Check: tfresource.TestCheckResourceAttr("yoloexp_notion_block.test", "table.rows.0.Owner", "team-c"),
+			},
+		},
+	})
+}
+
+func TestLoadTableGrid(t *testing.T) {
+	m := &notionTableBlockModel{
+		SourceFile:      types.StringNull(),
+		HasColumnHeader: types.BoolValue(true),
+		Rows: []map[string]types.String{
+			{"Service": types.StringValue("api"), "Owner": types.StringValue("team-a")},
+			{"Service": types.StringValue("web")},
+		},
+	}
+	if ok, err := loadTableGrid(m); !ok || err != nil {
+		t.Fatalf("loadTableGrid() = %v, %v", ok, err)
+	}
+	want := [][]string{{"Owner", "Service"}, {"team-a", "api"}, {"", "web"}}
+	if !reflect.DeepEqual(m.Grid, want) {
+		t.Errorf("grid = %q, want %q", m.Grid, want)
+	}
+
+	m.Columns = []types.String{types.StringValue("Service")}
+	if _, err := loadTableGrid(m); err == nil {
+		t.Error("loadTableGrid() accepted a row with a value for an unknown column")
+	}
+
+	m.Rows[0]["Owner"] = types.StringUnknown()
+	m.Columns = nil
+	if ok, err := loadTableGrid(m); ok || err != nil {
+		t.Errorf("loadTableGrid() = %v, %v for unknown rows", ok, err)
+	}
+
+	source := filepath.Join(t.TempDir(), "oncall.csv")
+	if err := os.WriteFile(source, []byte("Week,Primary,Secondary\n1,alice\n2,bob,\"carol, dave\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m = &notionTableBlockModel{SourceFile: types.StringValue(source), HasColumnHeader: types.BoolValue(true)}
+	if ok, err := loadTableGrid(m); !ok || err != nil {
+		t.Fatalf("loadTableGrid() = %v, %v", ok, err)
+	}
+	want = [][]string{{"Week", "Primary", "Secondary"}, {"1", "alice", ""}, {"2", "bob", "carol, dave"}}
+	if !reflect.DeepEqual(m.Grid, want) {
+		t.Errorf("grid = %q, want %q", m.Grid, want)
+	}
+}
+
+func TestFlattenTable(t *testing.T) {
+	grid := [][]string{{"Service", "Owner"}, {"api", ""}}
+	table := notionapi.Table{TableWidth: 2, HasColumnHeader: true, Children: tableRowBlocks(grid)}
+
+	m := flattenTable(table, nil)
+	if len(m.Columns) != 2 || m.Columns[1].ValueString() != "Owner" {
+		t.Errorf("columns = %v", m.Columns)
+	}
+	if len(m.Rows) != 1 || len(m.Rows[0]) != 1 || m.Rows[0]["Service"].ValueString() != "api" {
+		t.Errorf("rows = %v", m.Rows)
+	}
+	if m.ContentSHA256.ValueString() != gridSHA256(grid) {
+		t.Errorf("content_sha256 = %s, want %s", m.ContentSHA256.ValueString(), gridSHA256(grid))
+	}
+
+	prior := &notionTableBlockModel{
+		SourceFile:    types.StringValue("services.csv"),
+		ContentSHA256: types.StringValue(gridSHA256([][]string{{"Service", "Owner"}, {"api", "team-a"}})),
+	}
+	m = flattenTable(table, prior)
+	if m.SourceFile != prior.SourceFile || m.ContentSHA256.Equal(prior.ContentSHA256) {
+		t.Errorf("flattenTable() = %+v, want the prior source with a changed checksum", m)
+	}
+}
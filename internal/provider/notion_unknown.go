@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// unknownModelValues reports whether the value of any of the given root
+// attributes of a raw config or plan, or a list, set, map or object nested in
+// it, is unknown, as when it is built from values that are only known after
+// apply. The resource models hold such values in Go slices, maps and struct
+// pointers, which can't be unknown, so the config or plan can only be read
+// into the model when this is false. Unknown strings, numbers and bools are
+// fine, the model holds them as framework values.
+func unknownModelValues(raw tftypes.Value, names ...string) bool {
+	if !raw.IsKnown() {
+		return true
+	}
+	for _, name := range names {
+		v, _, err := tftypes.WalkAttributePath(raw, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			continue
+		}
+		if v, ok := v.(tftypes.Value); ok && hasUnknownStructure(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasUnknownStructure reports whether v, or a value nested in it, is an
+// unknown list, set, map, tuple or object.
+func hasUnknownStructure(v tftypes.Value) bool {
+	if !v.IsKnown() {
+		return !isPrimitiveType(v.Type())
+	}
+	if v.IsNull() || isPrimitiveType(v.Type()) {
+		return false
+	}
+
+	var elems []tftypes.Value
+	var fields map[string]tftypes.Value
+	switch {
+	case v.Type().Is(tftypes.Object{}), v.Type().Is(tftypes.Map{}):
+		if err := v.As(&fields); err != nil {
+			return false
+		}
+	default:
+		if err := v.As(&elems); err != nil {
+			return false
+		}
+	}
+	for _, e := range elems {
+		if hasUnknownStructure(e) {
+			return true
+		}
+	}
+	for _, e := range fields {
+		if hasUnknownStructure(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrimitiveType(t tftypes.Type) bool {
+	return t.Is(tftypes.String) || t.Is(tftypes.Number) || t.Is(tftypes.Bool) || t.Is(tftypes.DynamicPseudoType)
+}
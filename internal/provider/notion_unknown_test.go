@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateConfigUnknownCollections(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		r       resource.ResourceWithValidateConfig
+		unknown string
+	}{
+		"rows":       {&notionDatabaseRowsResource{}, "rows"},
+		"properties": {&notionDatabaseResource{}, "properties"},
+		"icon":       {&notionPageResource{}, "icon"},
+		"table":      {&notionBlockResource{}, "table"},
+	} {
+		var s resource.SchemaResponse
+		tc.r.Schema(ctx, resource.SchemaRequest{}, &s)
+		typ := s.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for k, at := range typ.AttributeTypes {
+			values[k] = tftypes.NewValue(at, nil)
+		}
+		values[tc.unknown] = tftypes.NewValue(typ.AttributeTypes[tc.unknown], tftypes.UnknownValue)
+
+		config := tfsdk.Config{Schema: s.Schema, Raw: tftypes.NewValue(typ, values)}
+		if !unknownModelValues(config.Raw, tc.unknown) {
+			t.Errorf("%s: unknownModelValues() = false", name)
+		}
+		var resp resource.ValidateConfigResponse
+		tc.r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: ValidateConfig() = %v", name, resp.Diagnostics)
+		}
+	}
+}
+
+func TestHasUnknownStructure(t *testing.T) {
+	row := tftypes.Map{ElementType: tftypes.String}
+	rows := tftypes.List{ElementType: row}
+
+	unknownCell := tftypes.NewValue(rows, []tftypes.Value{
+		tftypes.NewValue(row, map[string]tftypes.Value{"Owner": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+	})
+	if hasUnknownStructure(unknownCell) {
+		t.Error("hasUnknownStructure() = true for an unknown string")
+	}
+	unknownRow := tftypes.NewValue(rows, []tftypes.Value{tftypes.NewValue(row, tftypes.UnknownValue)})
+	if !hasUnknownStructure(unknownRow) {
+		t.Error("hasUnknownStructure() = false for an unknown map")
+	}
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -89,10 +90,7 @@ func (p *YoloProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	// Create a notion client.
-	client := notionapi.NewClient(
-		notionapi.Token(notionSecret),
-		notionapi.WithRetry(3),
-	)
+	client := newNotionClient(notionapi.Token(notionSecret), http.DefaultClient)
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -101,6 +99,9 @@ func (p *YoloProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *YoloProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNotionDatabaseResource,
+		NewNotionPageResource,
+		NewNotionDatabaseRowsResource,
+		NewNotionBlockResource,
 	}
 }
 
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -23,3 +24,13 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testAccParentPageID returns the id of the page acceptance tests create their
+// objects under. Tests that need one are skipped when it is not set.
+func testAccParentPageID(t *testing.T) string {
+	id := os.Getenv("NOTION_TEST_PARENT_PAGE_ID")
+	if id == "" {
+		t.Skip("NOTION_TEST_PARENT_PAGE_ID must be set for this acceptance test")
+	}
+	return id
+}